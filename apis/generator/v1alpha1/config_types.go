@@ -27,6 +27,33 @@ type ConfigSpec struct {
 
 	// Groups allows you to specify what groups you want to include instead of only resources
 	Groups []string `json:"groups,omitempty"`
+
+	// Source is where the CloudFormation Resource Specification is loaded from, this can be a URL,
+	// a JSON file, a gzipped JSON file or a directory of per-service specifications
+	Source string `json:"source,omitempty"`
+
+	// Format is the format of the source, either specification (default) or registry for a
+	// directory of CloudFormation registry resource provider schemas
+	Format string `json:"format,omitempty"`
+
+	// Version pins the ResourceSpecificationVersion, generating fails if the loaded specification doesn't match,
+	// registry schemas don't have a version so it can't be set for the registry format
+	Version string `json:"version,omitempty"`
+
+	// SHA256 pins the checksum of the specification, generating fails if the loaded specification doesn't match
+	SHA256 string `json:"sha256,omitempty"`
+
+	// Filters includes or excludes attributes and properties per resource, keyed by group:kind
+	// like the resources, they are merged with the default filters shipped with the generator
+	Filters map[string]Filter `json:"filters,omitempty"`
+
+	// Overrides change the naming, reference detection and shape of properties per resource,
+	// keyed by group:kind like the resources
+	Overrides map[string]Override `json:"overrides,omitempty"`
+
+	// TemplatesDir is a directory of <name>.tmpl templates which replace the default templates,
+	// the templates which aren't in the directory use the defaults
+	TemplatesDir string `json:"templatesDir,omitempty"`
 }
 
 // ConfigStatus defines the observed state of Config
@@ -66,5 +93,7 @@ func (c *Config) SetDefaults() error {
 	}
 	c.TypeMeta = typeMeta
 
-	return nil
+	c.setDefaultOverrides()
+
+	return c.setDefaultFilters()
 }
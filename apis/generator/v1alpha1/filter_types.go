@@ -0,0 +1,122 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the &#34;License&#34;);
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an &#34;AS IS&#34; BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Filter lists the attributes and properties which are generated for a resource
+type Filter struct {
+	// Attributes filters the attributes which are exported as stack outputs
+	Attributes FilterList `json:"attributes,omitempty"`
+
+	// Properties filters the spec properties, properties of property types are
+	// named <PropertyType>.<Property>
+	Properties FilterList `json:"properties,omitempty"`
+}
+
+// FilterList includes or excludes names, when Include is set only those names are generated
+type FilterList struct {
+	// Include lists the only names which are generated
+	Include []string `json:"include,omitempty"`
+
+	// Exclude lists the names which are never generated
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Allowed will check if the name passes the include and exclude lists
+func (in FilterList) Allowed(name string) bool {
+	for _, exclude := range in.Exclude {
+		if exclude == name {
+			return false
+		}
+	}
+
+	if len(in.Include) == 0 {
+		return true
+	}
+
+	for _, include := range in.Include {
+		if include == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFilter returns the filter for the group and kind
+func (c *Config) GetFilter(group, kind string) Filter {
+	return c.Spec.Filters[strings.ToLower(group+":"+kind)]
+}
+
+//go:generate go run ../../../hack/filters ../../../hack/boilerplate.go.txt filters.yaml zz_generated.filters.go v1alpha1
+
+// setDefaultFilters will merge the default filters in filters.yaml with the
+// configured filters
+func (c *Config) setDefaultFilters() error {
+	filters := map[string]Filter{}
+	if err := yaml.Unmarshal([]byte(defaultFilters), &filters); err != nil {
+		return err
+	}
+
+	for key, filter := range c.Spec.Filters {
+		key = strings.ToLower(key)
+		filters[key] = filters[key].merge(filter)
+	}
+	c.Spec.Filters = filters
+
+	return nil
+}
+
+// merge returns the filter with the lists of the overlay merged in
+func (in Filter) merge(overlay Filter) Filter {
+	return Filter{
+		Attributes: in.Attributes.merge(overlay.Attributes),
+		Properties: in.Properties.merge(overlay.Properties),
+	}
+}
+
+// merge returns the union of both lists, a name which the overlay includes is
+// no longer excluded and a name which the overlay excludes is no longer included
+func (in FilterList) merge(overlay FilterList) FilterList {
+	merged := FilterList{}
+	for _, include := range in.Include {
+		if !contains(overlay.Exclude, include) {
+			merged.Include = append(merged.Include, include)
+		}
+	}
+	for _, exclude := range in.Exclude {
+		if !contains(overlay.Include, exclude) {
+			merged.Exclude = append(merged.Exclude, exclude)
+		}
+	}
+	merged.Include = append(merged.Include, overlay.Include...)
+	merged.Exclude = append(merged.Exclude, overlay.Exclude...)
+	return merged
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"go.awsctrl.io/generator/apis/generator/v1alpha1"
+)
+
+func TestFilterList_Allowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter v1alpha1.FilterList
+		want   bool
+	}{
+		{"TestEmptyAllows", v1alpha1.FilterList{}, true},
+		{"TestExcluded", v1alpha1.FilterList{Exclude: []string{"Arn"}}, false},
+		{"TestIncluded", v1alpha1.FilterList{Include: []string{"Arn"}}, true},
+		{"TestNotIncluded", v1alpha1.FilterList{Include: []string{"Name"}}, false},
+		{"TestExcludeWins", v1alpha1.FilterList{Include: []string{"Arn"}, Exclude: []string{"Arn"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Allowed("Arn"); got != tt.want {
+				t.Errorf("FilterList.Allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_SetDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters map[string]v1alpha1.Filter
+		want    map[string]bool
+	}{
+		{"TestDefaults", nil, map[string]bool{"DistributionDomainName": false, "DistributionHostedZoneId": false, "Arn": true}},
+		{"TestMergesExclude", map[string]v1alpha1.Filter{"ApiGateway:DomainName": {Attributes: v1alpha1.FilterList{Exclude: []string{"Arn"}}}}, map[string]bool{"DistributionDomainName": false, "DistributionHostedZoneId": false, "Arn": false}},
+		{"TestIncludeWins", map[string]v1alpha1.Filter{"apigateway:domainname": {Attributes: v1alpha1.FilterList{Include: []string{"DistributionDomainName", "Arn"}}}}, map[string]bool{"DistributionDomainName": true, "DistributionHostedZoneId": false, "Arn": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &v1alpha1.Config{Spec: v1alpha1.ConfigSpec{Filters: tt.filters}}
+			if err := cfg.SetDefaults(); err != nil {
+				t.Fatalf("Config.SetDefaults() error = %v", err)
+			}
+
+			filter := cfg.GetFilter("apigateway", "DomainName")
+			for name, want := range tt.want {
+				if got := filter.Attributes.Allowed(name); got != want {
+					t.Errorf("Config.GetFilter() allowed %v = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_SetDefaultsGenerated(t *testing.T) {
+	body, err := ioutil.ReadFile("filters.yaml")
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile() error = %v", err)
+	}
+	want := map[string]v1alpha1.Filter{}
+	if err := yaml.Unmarshal(body, &want); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	cfg := &v1alpha1.Config{}
+	if err := cfg.SetDefaults(); err != nil {
+		t.Fatalf("Config.SetDefaults() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(cfg.Spec.Filters, want) {
+		t.Errorf("Config.SetDefaults() filters = %v, want %v, run make generate", cfg.Spec.Filters, want)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the &#34;License&#34;);
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an &#34;AS IS&#34; BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// Override changes how the properties of a resource are generated
+type Override struct {
+	// Properties are keyed by the property path, properties of property types
+	// are named <PropertyType>.<Property>
+	Properties map[string]PropertyOverride `json:"properties,omitempty"`
+}
+
+// PropertyOverride changes how a single property is generated
+type PropertyOverride struct {
+	// Reference forces or suppresses converting Id and Arn properties into ObjectReferences
+	Reference *bool `json:"reference,omitempty"`
+
+	// Kinds sets the group.Kind candidates an ObjectReference points to, instead of inferring them
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Name renames the Go field
+	Name string `json:"name,omitempty"`
+
+	// JSONName changes the JSON name of the field
+	JSONName string `json:"jsonName,omitempty"`
+
+	// Required marks the property as required or optional
+	Required *bool `json:"required,omitempty"`
+
+	// Drop removes the property from the generated resource
+	Drop bool `json:"drop,omitempty"`
+}
+
+// GetOverride returns the override for the group and kind
+func (c *Config) GetOverride(group, kind string) Override {
+	return c.Spec.Overrides[strings.ToLower(group+":"+kind)]
+}
+
+// setDefaultOverrides will lower case the group:kind keys of the overrides
+func (c *Config) setDefaultOverrides() {
+	overrides := map[string]Override{}
+	for key, override := range c.Spec.Overrides {
+		overrides[strings.ToLower(key)] = override
+	}
+	c.Spec.Overrides = overrides
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"go.awsctrl.io/generator/apis/generator/v1alpha1"
+)
+
+func TestConfig_GetOverride(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		Spec: v1alpha1.ConfigSpec{
+			Overrides: map[string]v1alpha1.Override{
+				"KMS:Alias": {Properties: map[string]v1alpha1.PropertyOverride{"AliasName": {Drop: true}}},
+			},
+		},
+	}
+
+	if err := cfg.SetDefaults(); err != nil {
+		t.Fatalf("Config.SetDefaults() error = %v", err)
+	}
+
+	if _, ok := cfg.Spec.Overrides["kms:alias"]; !ok {
+		t.Errorf("Config.SetDefaults() overrides = %v, want lower case keys", cfg.Spec.Overrides)
+	}
+
+	if !cfg.GetOverride("kms", "Alias").Properties["AliasName"].Drop {
+		t.Errorf("Config.GetOverride() didn't return the configured override")
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"go.awsctrl.io/generator/pkg/cfnspec"
+)
+
+var diffFrom string
+var diffTo string
+var diffOutput string
+
+// diffSpecCmd represents the diff-spec command
+var diffSpecCmd = &cobra.Command{
+	Use:   "diff-spec",
+	Short: "diff-spec will list the changes between two CloudFormation Resource Specs",
+	Long: `diff-spec compares two CloudFormation Resource Specifications and lists the
+added and removed resource types, property and attribute changes for the
+groups and resources in the config.
+
+  $ generator diff-spec --to ./specs/CloudFormationResourceSpecification.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := afero.NewOsFs()
+
+		fromPin := cfnspec.Pin{}
+		if diffFrom == "" {
+			diffFrom = cfg.Spec.Source
+			fromPin = cfnspec.Pin{Version: cfg.Spec.Version, SHA256: cfg.Spec.SHA256}
+		}
+
+		from, err := loadSpecification(fs, diffFrom, fromPin)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		to, err := loadSpecification(fs, diffTo, cfnspec.Pin{})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		diff := cfnspec.Compare(from, to, cfg.Spec.Groups, cfg.Spec.Resources)
+
+		switch diffOutput {
+		case "json":
+			data, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "text":
+			fmt.Println(diff.String())
+		default:
+			fmt.Printf("unknown output format %v\n", diffOutput)
+			os.Exit(1)
+		}
+	},
+}
+
+func loadSpecification(fs afero.Fs, source string, pin cfnspec.Pin) (*cfnspec.CloudFormationResourceSpecification, error) {
+	loader, err := newLoader(fs, source, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := cfnspec.New(loader, cfg.Spec.Groups, cfg.Spec.Resources)
+	if err := spec.Parse(); err != nil {
+		return nil, err
+	}
+
+	return spec.GetSpecification(), nil
+}
+
+func init() {
+	diffSpecCmd.Flags().StringVar(&diffFrom, "from", "", "Source of the specification to compare from, defaults to the configured source.")
+	diffSpecCmd.Flags().StringVar(&diffTo, "to", "", "Source of the specification to compare to.")
+	diffSpecCmd.Flags().StringVarP(&diffOutput, "output", "o", "text", "Output format. One of 'text' or 'json'.")
+	diffSpecCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(diffSpecCmd)
+}
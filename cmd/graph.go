@@ -0,0 +1,86 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"go.awsctrl.io/generator/pkg/reference"
+)
+
+var graphGroups []string
+var graphOutput string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "graph will output the ObjectReference dependencies between the generated kinds",
+	Long: `graph lists the inferred ObjectReference edges between the generated kinds
+as a DOT, Mermaid or JSON graph and reports reference cycles.
+
+  $ generator graph --group ec2 -o dot | dot -Tsvg > graph.svg`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := afero.NewOsFs()
+
+		spec, err := newSpec(fs)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		graph := reference.NewGraph(getResources(spec), graphGroups)
+
+		switch graphOutput {
+		case "dot":
+			fmt.Println(graph.Dot())
+		case "mermaid":
+			fmt.Println(graph.Mermaid())
+		case "json":
+			data, err := json.MarshalIndent(graph, "", "  ")
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		default:
+			fmt.Printf("unknown output format %v\n", graphOutput)
+			os.Exit(1)
+		}
+
+		// cycles go to stderr so the graph can still be piped
+		for _, cycle := range graph.Cycles {
+			names := []string{}
+			for _, kind := range cycle {
+				names = append(names, kind.String())
+			}
+			fmt.Fprintf(os.Stderr, "reference cycle between %v\n", strings.Join(names, ", "))
+		}
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringSliceVar(&graphGroups, "group", []string{}, "Only include references from these groups.")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "dot", "Output format. One of 'dot', 'mermaid' or 'json'.")
+
+	rootCmd.AddCommand(graphCmd)
+}
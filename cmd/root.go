@@ -66,4 +66,8 @@ func initConfig() {
 		fmt.Println(err)
 		os.Exit(1)
 	}
+	if err := cfg.SetDefaults(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
@@ -16,8 +16,10 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -25,12 +27,22 @@ import (
 	"go.awsctrl.io/generator/pkg/api"
 	"go.awsctrl.io/generator/pkg/cfnspec"
 	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/scaffold"
 
 	kbinput "sigs.k8s.io/kubebuilder/pkg/scaffold/input"
 )
 
 var boilerplatePath string
 var projectPath string
+var allowBreaking bool
+var force bool
+var keepGoing bool
+var reportPath string
+var concurrency int
+var dryRun bool
+var showDiff bool
+var prune bool
+var templatesDir string
 
 // runCmd represents the run command
 var runCmd = &cobra.Command{
@@ -39,35 +51,93 @@ var runCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 		fs := afero.NewOsFs()
+		if dryRun || showDiff {
+			// the files are written to memory on top of the read only tree
+			fs = afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), afero.NewMemMapFs())
+		}
 
 		options := input.Options{
 			Options: kbinput.Options{
 				BoilerplatePath: boilerplatePath,
 				ProjectPath:     projectPath,
 			},
+			AllowBreaking: allowBreaking,
+			Force:         force,
+			KeepGoing:     keepGoing,
+			Concurrency:   concurrency,
+			Prune:         prune,
+			DryRun:        dryRun || showDiff,
+			Version:       version,
+			TemplatesDir:  getTemplatesDir(),
 		}
 
-		builder := api.New(fs, options)
-		spec := cfnspec.New(cfg.Spec.Groups, cfg.Spec.Resources)
-
-		if err := spec.Parse(); err != nil {
+		spec, err := newSpec(fs)
+		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		options.SpecVersion = spec.GetSpecification().ResourceSpecificationVersion
+
+		builder := api.New(fs, options)
+
+		if cfg.Spec.Format == cfnspec.RegistryFormat && cfg.Spec.SHA256 == "" {
+			fmt.Printf("specification is not pinned, set spec.sha256 to %q\n", spec.GetChecksum())
+		} else if cfg.Spec.Format != cfnspec.RegistryFormat && (cfg.Spec.Version == "" || cfg.Spec.SHA256 == "") {
+			fmt.Printf("specification is not pinned, set spec.version to %q and spec.sha256 to %q\n", spec.GetSpecification().ResourceSpecificationVersion, spec.GetChecksum())
+		}
+
+		report, err := builder.BuildAll(getResources(spec))
 
-		resources := spec.GetResources()
-		for _, r := range resources {
-			if err := builder.Build(&r, resources); err != nil {
-				fmt.Println(err)
+		for _, change := range builder.GetChanges() {
+			if showDiff {
+				fmt.Print(change.Diff())
+			} else if dryRun && change.Edited && change.Action == scaffold.OverwriteAction && !force {
+				fmt.Printf("%v %v (edited since it was generated, use --force to overwrite it)\n", change.Action, change.Path)
+			} else if dryRun {
+				fmt.Printf("%v %v\n", change.Action, change.Path)
+			}
+		}
+
+		fmt.Println(report)
+
+		if reportPath != "" {
+			body, jsonErr := json.MarshalIndent(report, "", "  ")
+			if jsonErr == nil {
+				jsonErr = afero.WriteFile(afero.NewOsFs(), reportPath, body, 0644)
+			}
+			if jsonErr != nil {
+				fmt.Println(jsonErr)
 				os.Exit(1)
 			}
 		}
+
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	},
 }
 
+// getTemplatesDir returns the templates directory from the flag or the config
+func getTemplatesDir() string {
+	if templatesDir != "" {
+		return templatesDir
+	}
+	return cfg.Spec.TemplatesDir
+}
+
 func init() {
 	runCmd.Flags().StringVarP(&boilerplatePath, "boilerplate-path", "b", "./hack/boilerplate.go.txt", "Path to the boilerplate header.")
 	runCmd.Flags().StringVarP(&projectPath, "project-path", "p", "./PROJECT", "Path to the project file.")
+	runCmd.Flags().BoolVar(&allowBreaking, "allow-breaking", false, "Override types even when the CRD has breaking changes.")
+	runCmd.Flags().BoolVar(&force, "force", false, "Overwrite the generated files which were edited since they were generated.")
+	runCmd.Flags().BoolVar(&keepGoing, "keep-going", false, "Skip the resources which fail to generate instead of stopping.")
+	runCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Number of resources to generate in parallel.")
+	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the files which would be created, overwritten or left untouched without writing them.")
+	runCmd.Flags().BoolVar(&showDiff, "diff", false, "Print the changes to the files as unified diffs without writing them.")
+	runCmd.Flags().BoolVar(&prune, "prune", true, "Delete the generated files which are no longer generated, they're only reported when it's false.")
+	runCmd.Flags().StringVar(&templatesDir, "templates-dir", "", "Directory of templates which replace the defaults, overrides spec.templatesDir.")
+	runCmd.Flags().StringVar(&reportPath, "report", "", "Path to write the generation report as JSON.")
 
 	rootCmd.AddCommand(runCmd)
 }
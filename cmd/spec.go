@@ -0,0 +1,87 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"go.awsctrl.io/generator/pkg/cfnspec"
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+var cacheDir string
+
+// newSpec will load and parse the configured specification
+func newSpec(fs afero.Fs) (cfnspec.CFNSpec, error) {
+	pin := cfnspec.Pin{
+		Version: cfg.Spec.Version,
+		SHA256:  cfg.Spec.SHA256,
+	}
+
+	if cfg.Spec.Format == cfnspec.RegistryFormat && pin.Version != "" {
+		return nil, fmt.Errorf("spec.version can't be pinned for the %v format, registry schemas don't have a version, pin spec.sha256 instead", cfnspec.RegistryFormat)
+	}
+
+	loader, err := newLoader(fs, cfg.Spec.Source, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := cfnspec.New(loader, cfg.Spec.Groups, cfg.Spec.Resources)
+	if err := spec.Parse(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// getResources returns the included resources with their configured filters
+// and overrides
+func getResources(spec cfnspec.CFNSpec) []resource.Resource {
+	resources := spec.GetResources()
+	for i := range resources {
+		resources[i].Filter = cfg.GetFilter(resources[i].Group, resources[i].Kind)
+		resources[i].Override = cfg.GetOverride(resources[i].Group, resources[i].Kind)
+	}
+	return resources
+}
+
+// newLoader will return a caching loader for the source
+func newLoader(fs afero.Fs, source string, pin cfnspec.Pin) (cfnspec.Loader, error) {
+	loader, err := cfnspec.NewLoader(fs, source, cfg.Spec.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfnspec.NewCacheLoader(fs, cacheDir, pin, loader), nil
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "awsctrl-generator")
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", defaultCacheDir(), "Directory to cache specifications in, empty disables caching.")
+}
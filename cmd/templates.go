@@ -0,0 +1,76 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"go.awsctrl.io/generator/pkg/api"
+	"go.awsctrl.io/generator/pkg/scaffold"
+)
+
+var exportDir string
+var exportForce bool
+
+// exportTemplatesCmd represents the export-templates command
+var exportTemplatesCmd = &cobra.Command{
+	Use:   "export-templates",
+	Short: "export-templates will write the default templates to a directory",
+	Long: `export-templates writes the default templates as <name>.tmpl files, edit them
+and set spec.templatesDir or --templates-dir to generate the files with them.
+Existing templates are kept unless --force is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := afero.NewOsFs()
+
+		if err := fs.MkdirAll(exportDir, 0755); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		for _, template := range api.Templates() {
+			path := filepath.Join(exportDir, scaffold.TemplateFile(template))
+
+			exist, err := afero.Exists(fs, path)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			if exist && !exportForce {
+				fmt.Printf("skip %v\n", path)
+				continue
+			}
+
+			if err := afero.WriteFile(fs, path, []byte(template.DefaultTemplate()), 0644); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Printf("write %v\n", path)
+		}
+	},
+}
+
+func init() {
+	exportTemplatesCmd.Flags().StringVarP(&exportDir, "dir", "d", "./templates", "Directory to write the templates to.")
+	exportTemplatesCmd.Flags().BoolVar(&exportForce, "force", false, "Overwrite the existing templates.")
+
+	rootCmd.AddCommand(exportTemplatesCmd)
+}
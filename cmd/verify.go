@@ -0,0 +1,117 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"go.awsctrl.io/generator/pkg/api"
+	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/scaffold"
+
+	kbinput "sigs.k8s.io/kubebuilder/pkg/scaffold/input"
+)
+
+// verifyDirs are the directories checked by verify
+var verifyDirs = []string{"apis", "controllers", "config", "e2e"}
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify will check the generated files are up to date",
+	Long: `verify generates the files into memory and lists the files under apis/,
+controllers/, config/ and e2e/ which are missing, differ from the files on
+disk or are no longer generated, it exits non-zero when any file is stale.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), afero.NewMemMapFs())
+
+		options := input.Options{
+			Options: kbinput.Options{
+				BoilerplatePath: boilerplatePath,
+				ProjectPath:     projectPath,
+			},
+			AllowBreaking: true,
+			KeepGoing:     true,
+			Concurrency:   concurrency,
+			Prune:         true,
+			DryRun:        true,
+			Version:       version,
+			TemplatesDir:  getTemplatesDir(),
+		}
+
+		spec, err := newSpec(fs)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		options.SpecVersion = spec.GetSpecification().ResourceSpecificationVersion
+
+		builder := api.New(fs, options)
+
+		_, err = builder.BuildAll(getResources(spec))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		stale := 0
+		for _, change := range builder.GetChanges() {
+			if !isStale(change) {
+				continue
+			}
+			fmt.Printf("%v %v\n", change.Action, change.Path)
+			stale++
+		}
+
+		if stale > 0 {
+			fmt.Printf("%v generated files are stale, run generator run to update them\n", stale)
+			os.Exit(1)
+		}
+	},
+}
+
+// isStale checks if a generated file in the verified directories is missing,
+// differs or is no longer generated
+func isStale(change scaffold.Change) bool {
+	switch change.Action {
+	case scaffold.CreateAction, scaffold.OverwriteAction, scaffold.DeleteAction:
+	default:
+		return false
+	}
+	for _, dir := range verifyDirs {
+		if strings.HasPrefix(filepath.ToSlash(filepath.Clean(change.Path)), dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&boilerplatePath, "boilerplate-path", "b", "./hack/boilerplate.go.txt", "Path to the boilerplate header.")
+	verifyCmd.Flags().StringVarP(&projectPath, "project-path", "p", "./PROJECT", "Path to the project file.")
+	verifyCmd.Flags().StringVar(&templatesDir, "templates-dir", "", "Directory of templates which replace the defaults, overrides spec.templatesDir.")
+	verifyCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Number of resources to generate in parallel.")
+
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"go.awsctrl.io/generator/pkg/scaffold"
+)
+
+func TestIsStale(t *testing.T) {
+	tests := []struct {
+		name   string
+		change scaffold.Change
+		want   bool
+	}{
+		{"TestCreatedInApis", scaffold.Change{Path: "apis/ecr/v1alpha1/repository_types.go", Action: scaffold.CreateAction}, true},
+		{"TestOverwrittenInControllers", scaffold.Change{Path: "controllers/ecr/repository_controller.go", Action: scaffold.OverwriteAction}, true},
+		{"TestDeletedInConfig", scaffold.Change{Path: "config/samples/ecr/v1alpha1_repository.yaml", Action: scaffold.DeleteAction}, true},
+		{"TestCreatedInE2E", scaffold.Change{Path: "./e2e/ecr/repository_test.go", Action: scaffold.CreateAction}, true},
+		{"TestUnchangedInApis", scaffold.Change{Path: "apis/ecr/v1alpha1/repository_types.go", Action: scaffold.UnchangedAction}, false},
+		{"TestSkippedInE2E", scaffold.Change{Path: "e2e/ecr/repository_test.go", Action: scaffold.SkipAction}, false},
+		{"TestCreatedOutside", scaffold.Change{Path: "PROJECT", Action: scaffold.CreateAction}, false},
+		{"TestOverwrittenOutside", scaffold.Change{Path: ".generator-manifest.yaml", Action: scaffold.OverwriteAction}, false},
+		{"TestDeletedOutside", scaffold.Change{Path: "hack/boilerplate.go.txt", Action: scaffold.DeleteAction}, false},
+		{"TestDirectoryPrefix", scaffold.Change{Path: "apisx/ecr.go", Action: scaffold.CreateAction}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStale(tt.change); got != tt.want {
+				t.Errorf("isStale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
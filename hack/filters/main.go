@@ -0,0 +1,75 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// filters generates the Go file which holds the default filters overlay, it's
+// run by go generate in apis/generator/v1alpha1
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	if len(os.Args) != 5 {
+		fmt.Println("usage: filters <boilerplate> <filters.yaml> <output.go> <package>")
+		os.Exit(1)
+	}
+
+	if err := generate(os.Args[1], os.Args[2], os.Args[3], os.Args[4]); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func generate(boilerplatePath, filtersPath, outputPath, pkg string) error {
+	boilerplate, err := ioutil.ReadFile(boilerplatePath)
+	if err != nil {
+		return err
+	}
+
+	filters, err := ioutil.ReadFile(filtersPath)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(filters, &map[string]interface{}{}); err != nil {
+		return fmt.Errorf("%v: %v", filtersPath, err)
+	}
+
+	if strings.Contains(string(filters), "`") {
+		return fmt.Errorf("%v: backquotes aren't supported", filtersPath)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\n// Code generated by hack/filters from %v. DO NOT EDIT.\n\n", bytes.TrimSpace(boilerplate), filtersPath)
+	fmt.Fprintf(&out, "package %v\n\n", pkg)
+	fmt.Fprintf(&out, "// defaultFilters is the default filters overlay\n")
+	fmt.Fprintf(&out, "const defaultFilters = `%s`\n", filters)
+
+	body, err := format.Source(out.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outputPath, body, 0644)
+}
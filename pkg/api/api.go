@@ -17,8 +17,13 @@ limitations under the License.
 package api
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/spf13/afero"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
 	kbinput "sigs.k8s.io/kubebuilder/pkg/scaffold/input"
 
 	"go.awsctrl.io/generator/pkg/controller"
@@ -26,7 +31,9 @@ import (
 	"go.awsctrl.io/generator/pkg/e2e"
 	"go.awsctrl.io/generator/pkg/group"
 	"go.awsctrl.io/generator/pkg/kustomize"
+	"go.awsctrl.io/generator/pkg/manifest"
 	"go.awsctrl.io/generator/pkg/project"
+	"go.awsctrl.io/generator/pkg/reference"
 	"go.awsctrl.io/generator/pkg/stackobject"
 	"go.awsctrl.io/generator/pkg/types"
 	"go.awsctrl.io/generator/pkg/yaml"
@@ -45,6 +52,17 @@ type API struct {
 
 	// options contains CLI params
 	options input.Options
+
+	// previous is the manifest of the previous run, it's loaded by BuildAll
+	previous *manifest.File
+
+	// references indexes the resources for the ObjectReference kinds, it's
+	// built once by BuildAll
+	references *reference.Index
+
+	// changes records the generated files, it's shared by the workers
+	mux     sync.Mutex
+	changes []scaffold.Change
 }
 
 // New will generate an API builder
@@ -55,33 +73,212 @@ func New(fs afero.Fs, options input.Options) *API {
 	}
 }
 
-// Build will generate all the
+// Build will generate the files of a resource
 func (a *API) Build(r *resource.Resource, rs []resource.Resource) (err error) {
 	var in *input.Input
 	if in, err = a.setDefaults(); err != nil {
 		return err
 	}
 
-	files := []input.File{
-		&types.Types{Resource: r, Input: *in, Resources: rs},
-		&group.Group{Resource: r, Input: *in, Resources: rs},
+	files := resourceFiles(r, rs, in, a.references)
+
+	s := scaffold.New(a.fs, a.options)
+	s.SetManifest(a.previous)
+
+	if err := s.Execute(files...); err != nil {
+		return err
+	}
+	a.record(s.GetChanges())
+
+	return nil
+}
+
+// BuildProject will generate the files shared by the resources, the group
+// files are rendered once per group version and the rest once for all of them
+func (a *API) BuildProject(rs []resource.Resource) (err error) {
+	var in *input.Input
+	if in, err = a.setDefaults(); err != nil {
+		return err
+	}
+
+	files := []input.File{}
+
+	groups := map[string]bool{}
+	for i := range rs {
+		groupversion := rs[i].Group + "/" + rs[i].Version
+		if groups[groupversion] {
+			continue
+		}
+		groups[groupversion] = true
+
+		files = append(files, groupFiles(&rs[i], rs, in)...)
+	}
+
+	files = append(files,
+		&kustomize.CRD{Input: *in, Resources: rs},
+		&controllermanager.ControllerManager{Input: *in, Resources: rs},
+		&project.Project{Input: *in, Resources: rs},
+	)
+
+	s := scaffold.New(a.fs, a.options)
+	s.SetManifest(a.previous)
+
+	if err := s.Execute(files...); err != nil {
+		return err
+	}
+	a.record(s.GetChanges())
+
+	return nil
+}
+
+// Templates returns the files which can load their template from the templates directory
+func Templates() []input.Template {
+	return []input.Template{
+		&types.Types{},
+		&stackobject.StackObject{},
+		&controller.Controller{},
+		&yaml.YAML{},
+		&e2e.E2E{},
+		&group.Group{},
+		&e2e.Suite{},
+		&kustomize.CRD{},
+		&controllermanager.ControllerManager{},
+	}
+}
+
+func resourceFiles(r *resource.Resource, rs []resource.Resource, in *input.Input, references *reference.Index) []input.File {
+	return []input.File{
+		&types.Types{Resource: r, Input: *in, Resources: rs, References: references},
 		&stackobject.StackObject{Resource: r, Input: *in, Resources: rs},
 		&controller.Controller{Resource: r, Input: *in, Resources: rs},
-		&kustomize.CRD{Resource: r, Input: *in, Resources: rs},
 		&yaml.YAML{Resource: r, Input: *in, Resources: rs},
 		&e2e.E2E{Resource: r, Input: *in, Resources: rs},
+	}
+}
+
+// wasGenerated returns true when the files of the resource are in the manifest
+func wasGenerated(previous *manifest.File, r *resource.Resource, rs []resource.Resource) bool {
+	return previous.Has(resourceFiles(r, rs, &input.Input{}, nil)[0].GetInput().Path)
+}
+
+func groupFiles(r *resource.Resource, rs []resource.Resource, in *input.Input) []input.File {
+	return []input.File{
+		&group.Group{Resource: r, Input: *in, Resources: rs},
 		&e2e.Suite{Resource: r, Input: *in, Resources: rs},
-		&controllermanager.ControllerManager{Resource: r, Input: *in, Resources: rs},
-		&project.Project{Resource: r, Input: *in, Resources: rs},
 	}
+}
 
-	s := scaffold.New(a.fs)
+// BuildAll will generate the files for all the resources with Concurrency
+// workers, it stops at the first failing resource unless KeepGoing is set and
+// returns all the failures. The project files list the resources which were
+// generated and the failed resources which were generated by a previous run,
+// their files are kept.
+func (a *API) BuildAll(rs []resource.Resource) (*Report, error) {
+	previous, err := manifest.Load(a.fs, manifest.Path)
+	if err != nil {
+		return NewReport(), err
+	}
+	a.previous = previous
+	a.references = reference.New(rs)
 
-	if err := s.Execute(files...); err != nil {
-		return err
+	// each worker only writes the index it's building, they're read once all
+	// the workers are done
+	built := make([]bool, len(rs))
+	errs := make([]error, len(rs))
+
+	var mux sync.Mutex
+	stopped := false
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < a.concurrency(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mux.Lock()
+				stop := stopped
+				mux.Unlock()
+				if stop {
+					continue
+				}
+
+				built[i] = true
+				if errs[i] = a.Build(&rs[i], rs); errs[i] != nil && !a.options.KeepGoing {
+					mux.Lock()
+					stopped = true
+					mux.Unlock()
+				}
+			}
+		}()
 	}
+	for i := range rs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-	return nil
+	report := NewReport()
+	failures := []error{}
+	generated := []resource.Resource{}
+	for i := range rs {
+		switch {
+		case !built[i]:
+			report.skipped(&rs[i])
+		case errs[i] != nil:
+			report.failed(&rs[i], errs[i])
+			failures = append(failures, errs[i])
+			if wasGenerated(previous, &rs[i], rs) {
+				generated = append(generated, rs[i])
+			}
+		default:
+			report.succeeded(&rs[i])
+			generated = append(generated, rs[i])
+		}
+	}
+
+	if len(generated) > 0 && (len(failures) == 0 || a.options.KeepGoing) {
+		err := a.BuildProject(generated)
+		if err == nil {
+			err = a.buildManifest(previous, rs, built, errs, report)
+		}
+		if err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	for _, change := range a.GetChanges() {
+		if change.Edited && change.Action == scaffold.OverwriteAction {
+			report.Edited = append(report.Edited, change.Path)
+		}
+	}
+
+	return report, utilerrors.NewAggregate(failures)
+}
+
+// GetChanges returns what happened to the generated files ordered by path
+func (a *API) GetChanges() []scaffold.Change {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	changes := append([]scaffold.Change{}, a.changes...)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func (a *API) record(changes []scaffold.Change) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.changes = append(a.changes, changes...)
+}
+
+func (a *API) concurrency() int {
+	if a.options.Concurrency < 1 {
+		return 1
+	}
+	return a.options.Concurrency
 }
 
 func (a *API) setDefaults() (i *input.Input, err error) {
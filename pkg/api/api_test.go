@@ -17,13 +17,16 @@ limitations under the License.
 package api_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
 	"go.awsctrl.io/generator/pkg/api"
 	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/manifest"
 	"go.awsctrl.io/generator/pkg/resource"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	kbinput "sigs.k8s.io/kubebuilder/pkg/scaffold/input"
 	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
 )
@@ -117,3 +120,223 @@ func TestAPI_Build(t *testing.T) {
 }
 
 // TODO: Tests that test the contents of the files...
+
+// newResource returns a resource with a property of the property type of the
+// same name, it fails to generate when propertytypes doesn't have it
+func newResource(service, kind, property string, propertytypes map[string]resource.ResourceType) resource.Resource {
+	return resource.Resource{
+		Resource: kbresource.Resource{
+			Namespaced: true,
+			Group:      strings.ToLower(service),
+			Version:    "v1alpha1",
+			Kind:       kind,
+		},
+		ResourceName: "AWS::" + service + "::" + kind,
+		ResourceType: &resource.BaseResource{
+			Attributes: map[string]resource.Attribute{},
+			Properties: map[string]resource.Property{
+				property: &resource.BaseProperty{Type: property},
+			},
+		},
+		PropertyTypes: propertytypes,
+	}
+}
+
+func TestAPI_BuildAll(t *testing.T) {
+	valid := map[string]resource.ResourceType{
+		"LifecyclePolicy": &resource.BaseResource{Properties: map[string]resource.Property{}},
+	}
+
+	tests := []struct {
+		name        string
+		keepGoing   bool
+		concurrency int
+		resources   []resource.Resource
+		wantErrs    int
+		wantFile    string
+		want        string
+	}{
+		{"TestValid", false, 1, []resource.Resource{newResource("ECR", "Repository", "LifecyclePolicy", valid)}, 0, "apis/ecr/v1alpha1/groupversion_info.go", "generated 1 resources, 0 failed, 0 skipped"},
+		{"TestStopsAtFailure", false, 1, []resource.Resource{newResource("ECR", "Broken", "LifecyclePolicy", nil), newResource("ECR", "Other", "LifecyclePolicy", nil), newResource("ECR", "Repository", "LifecyclePolicy", valid)}, 1, "", "generated 0 resources, 1 failed, 2 skipped"},
+		{"TestKeepGoing", true, 1, []resource.Resource{newResource("ECR", "Broken", "LifecyclePolicy", nil), newResource("ECR", "Other", "LifecyclePolicy", nil), newResource("ECR", "Repository", "LifecyclePolicy", valid)}, 2, "controllers/controllermanager/controllermanager.go", "generated 1 resources, 2 failed, 0 skipped"},
+		{"TestConcurrentKeepGoing", true, 4, []resource.Resource{newResource("ECR", "Broken", "LifecyclePolicy", nil), newResource("ECR", "Other", "LifecyclePolicy", nil), newResource("ECR", "Repository", "LifecyclePolicy", valid), newResource("ECR", "Registry", "LifecyclePolicy", valid)}, 2, "controllers/controllermanager/controllermanager.go", "generated 2 resources, 2 failed, 0 skipped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			afero.WriteFile(fs, "./hack/boilerplate.go.txt", []byte("// LICENSE"), 0644)
+
+			a := api.New(fs, input.Options{
+				Options:     kbinput.Options{BoilerplatePath: "./hack/boilerplate.go.txt"},
+				KeepGoing:   tt.keepGoing,
+				Concurrency: tt.concurrency,
+			})
+
+			report, err := a.BuildAll(tt.resources)
+			if tt.wantErrs == 0 && err != nil {
+				t.Errorf("API.BuildAll() error = %v", err)
+			}
+
+			if got := strings.Split(report.String(), "\n")[0]; got != tt.want {
+				t.Errorf("API.BuildAll() report = %v, want %v", got, tt.want)
+			}
+
+			if len(report.Failed) > 0 && report.Failed[0].Resource != "AWS::ECR::Broken" {
+				t.Errorf("API.BuildAll() report = %v, want the failures in the resource order", report.Failed)
+			}
+
+			if tt.wantErrs > 0 {
+				aggregate, ok := err.(utilerrors.Aggregate)
+				if !ok || len(aggregate.Errors()) != tt.wantErrs {
+					t.Fatalf("API.BuildAll() error = %v, want %v errors", err, tt.wantErrs)
+				}
+				if !strings.Contains(err.Error(), "AWS::ECR::Broken: property LifecyclePolicy: property type LifecyclePolicy not found") {
+					t.Errorf("API.BuildAll() error = %v, want the resource and property path", err)
+				}
+			}
+
+			if _, err := fs.Stat("apis/ecr/v1alpha1/broken_types.go"); err == nil {
+				t.Errorf("API.BuildAll() created files for the failing resource")
+			}
+
+			if manager, err := afero.ReadFile(fs, "controllers/controllermanager/controllermanager.go"); err == nil && strings.Contains(string(manager), "BrokenReconciler") {
+				t.Errorf("API.BuildAll() registered the failing resource in the controller manager")
+			}
+
+			if tt.wantFile != "" {
+				if _, err := fs.Stat(tt.wantFile); err != nil {
+					t.Errorf("API.BuildAll() didn't create file %v", tt.wantFile)
+				}
+			}
+		})
+	}
+}
+
+func TestAPI_BuildAllPrune(t *testing.T) {
+	valid := map[string]resource.ResourceType{
+		"Policy": &resource.BaseResource{Properties: map[string]resource.Property{}},
+	}
+
+	tests := []struct {
+		name        string
+		prune       bool
+		resources   []resource.Resource
+		wantFiles   []string
+		wantRemoved []string
+		wantManager bool
+		want        string
+	}{
+		{"TestPrunesRemoved", true, []resource.Resource{newResource("Repository", "Repository", "Policy", valid)}, []string{"apis/repository/v1alpha1/repository_types.go", "user.go"}, []string{"apis/queue/v1alpha1/queue_types.go", "apis/queue", "config/samples/queue/v1alpha1_queue.yaml"}, false, "pruned apis/queue/v1alpha1/groupversion_info.go"},
+		{"TestReportsRemoved", false, []resource.Resource{newResource("Repository", "Repository", "Policy", valid)}, []string{"apis/queue/v1alpha1/queue_types.go"}, []string{}, false, "orphaned apis/queue/v1alpha1/groupversion_info.go"},
+		{"TestKeepsFailed", true, []resource.Resource{newResource("Queue", "Queue", "Policy", nil), newResource("Repository", "Repository", "Policy", valid)}, []string{"apis/queue/v1alpha1/queue_types.go", "apis/queue/v1alpha1/groupversion_info.go"}, []string{}, true, "failed AWS::Queue::Queue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			afero.WriteFile(fs, "./hack/boilerplate.go.txt", []byte("// LICENSE"), 0644)
+			afero.WriteFile(fs, "user.go", []byte("package user"), 0644)
+
+			options := input.Options{
+				Options:   kbinput.Options{BoilerplatePath: "./hack/boilerplate.go.txt"},
+				KeepGoing: true,
+				Prune:     tt.prune,
+			}
+			if _, err := api.New(fs, options).BuildAll([]resource.Resource{newResource("Queue", "Queue", "Policy", valid), newResource("Repository", "Repository", "Policy", valid)}); err != nil {
+				t.Fatalf("API.BuildAll() error = %v", err)
+			}
+
+			report, _ := api.New(fs, options).BuildAll(tt.resources)
+			if !strings.Contains(report.String(), tt.want) {
+				t.Errorf("API.BuildAll() report = %v, want %v", report, tt.want)
+			}
+
+			for _, path := range tt.wantFiles {
+				if _, err := fs.Stat(path); err != nil {
+					t.Errorf("API.BuildAll() removed %v", path)
+				}
+			}
+			for _, path := range tt.wantRemoved {
+				if _, err := fs.Stat(path); err == nil {
+					t.Errorf("API.BuildAll() didn't remove %v", path)
+				}
+			}
+
+			manager, err := afero.ReadFile(fs, "controllers/controllermanager/controllermanager.go")
+			if err != nil {
+				t.Fatalf("API.BuildAll() error = %v", err)
+			}
+			if got := strings.Contains(string(manager), "queue.QueueReconciler"); got != tt.wantManager {
+				t.Errorf("API.BuildAll() registered Queue in the controller manager = %v, want %v", got, tt.wantManager)
+			}
+		})
+	}
+}
+
+func TestAPI_BuildAllEdited(t *testing.T) {
+	r := newResource("ECR", "Repository", "LifecyclePolicy", map[string]resource.ResourceType{
+		"LifecyclePolicy": &resource.BaseResource{Properties: map[string]resource.Property{}},
+	})
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "./hack/boilerplate.go.txt", []byte("// LICENSE"), 0644)
+
+	a := api.New(fs, input.Options{Options: kbinput.Options{BoilerplatePath: "./hack/boilerplate.go.txt"}, Version: "v1", SpecVersion: "10.0.0"})
+	if _, err := a.BuildAll([]resource.Resource{r}); err != nil {
+		t.Fatalf("API.BuildAll() error = %v", err)
+	}
+
+	m, err := manifest.Load(fs, manifest.Path)
+	if err != nil {
+		t.Fatalf("manifest.Load() error = %v", err)
+	}
+	entry, ok := m.Get("controllers/ecr/repository_controller.go")
+	if m.GeneratorVersion != "v1" || m.SpecVersion != "10.0.0" || !ok || entry.Type != "controller.Controller" || entry.SHA256 == "" {
+		t.Errorf("API.BuildAll() manifest = %+v", m)
+	}
+
+	afero.WriteFile(fs, "controllers/ecr/repository_controller.go", []byte("// edited"), 0600)
+	afero.WriteFile(fs, "e2e/ecr/repository_test.go", []byte("// edited"), 0600)
+
+	tests := []struct {
+		name     string
+		options  input.Options
+		wantErr  bool
+		wantFile string
+	}{
+		{"TestRefusesEdited", input.Options{}, true, "// edited"},
+		{"TestDryRunListsEdited", input.Options{DryRun: true}, false, "// edited"},
+		{"TestForceOverwritesEdited", input.Options{Force: true}, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Options = kbinput.Options{BoilerplatePath: "./hack/boilerplate.go.txt"}
+
+			runfs := fs
+			if tt.options.DryRun {
+				runfs = afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), afero.NewMemMapFs())
+			}
+
+			a := api.New(runfs, tt.options)
+			report, err := a.BuildAll([]resource.Resource{r})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("API.BuildAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "controllers/ecr/repository_controller.go: edited since it was generated, use --force to overwrite it") {
+				t.Errorf("API.BuildAll() error = %v, want the edited controller", err)
+			}
+
+			if !tt.wantErr && (len(report.Edited) != 1 || report.Edited[0] != "controllers/ecr/repository_controller.go") {
+				t.Errorf("API.BuildAll() edited = %v, want the edited controller", report.Edited)
+			}
+
+			controller, _ := afero.ReadFile(fs, "controllers/ecr/repository_controller.go")
+			if got := string(controller) == "// edited"; got != (tt.wantFile != "") {
+				t.Errorf("API.BuildAll() kept the edited controller = %v, want %v", got, tt.wantFile != "")
+			}
+
+			if e2e, _ := afero.ReadFile(fs, "e2e/ecr/repository_test.go"); string(e2e) != "// edited" {
+				t.Errorf("API.BuildAll() overwrote the e2e test which is only created once")
+			}
+		})
+	}
+}
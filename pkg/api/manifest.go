@@ -0,0 +1,100 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/manifest"
+	"go.awsctrl.io/generator/pkg/resource"
+	"go.awsctrl.io/generator/pkg/scaffold"
+)
+
+// buildManifest prunes the files in the previous manifest which weren't
+// generated and writes the new manifest, the files of the resources which
+// failed are kept
+func (a *API) buildManifest(previous *manifest.File, rs []resource.Resource, built []bool, errs []error, report *Report) error {
+	owned := map[string]manifest.Entry{}
+	for _, change := range a.GetChanges() {
+		if change.Action == scaffold.SkipAction {
+			// files which are only created once are owned when they were generated
+			if entry, ok := previous.Get(change.Path); ok {
+				owned[change.Path] = entry
+			}
+			continue
+		}
+		owned[change.Path] = manifest.Entry{
+			Path:   change.Path,
+			Type:   change.Type,
+			SHA256: manifest.Hash(scaffold.StripRegions(change.Contents)),
+		}
+	}
+
+	for i := range rs {
+		if built[i] && errs[i] == nil {
+			continue
+		}
+		files := append(resourceFiles(&rs[i], rs, &input.Input{}, nil), groupFiles(&rs[i], rs, &input.Input{})...)
+		for _, file := range files {
+			if entry, ok := previous.Get(file.GetInput().Path); ok {
+				owned[entry.Path] = entry
+			}
+		}
+	}
+
+	stale := []string{}
+	for _, entry := range previous.Files {
+		if _, ok := owned[entry.Path]; ok || entry.Path == manifest.Path {
+			continue
+		}
+		if !a.options.Prune {
+			report.Orphaned = append(report.Orphaned, entry.Path)
+			owned[entry.Path] = entry
+			continue
+		}
+		stale = append(stale, entry.Path)
+	}
+
+	s := scaffold.New(a.fs, a.options)
+
+	if err := s.Delete(stale...); err != nil {
+		return err
+	}
+	report.Pruned = append(report.Pruned, stale...)
+
+	files := []manifest.Entry{}
+	for _, entry := range owned {
+		files = append(files, entry)
+	}
+
+	in, err := a.setDefaults()
+	if err != nil {
+		return err
+	}
+
+	m := &manifest.Manifest{
+		Input:            *in,
+		GeneratorVersion: a.options.Version,
+		SpecVersion:      a.options.SpecVersion,
+		Files:            files,
+	}
+	if err := s.Execute(m); err != nil {
+		return err
+	}
+	a.record(s.GetChanges())
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+// Report summarizes the generated resources
+type Report struct {
+	// Succeeded lists the generated resources
+	Succeeded []string `json:"succeeded"`
+
+	// Failed lists the resources which failed to generate
+	Failed []Failure `json:"failed"`
+
+	// Skipped lists the resources which weren't generated after a failure
+	Skipped []string `json:"skipped"`
+
+	// Dropped lists the properties of the generated resources which were left out
+	Dropped []Dropped `json:"dropped"`
+
+	// SkippedPatterns lists the properties whose pattern Kubernetes can't compile
+	SkippedPatterns []Dropped `json:"skippedPatterns"`
+
+	// Pruned lists the owned files which were deleted because they're no longer generated
+	Pruned []string `json:"pruned"`
+
+	// Orphaned lists the owned files which are no longer generated but weren't pruned
+	Orphaned []string `json:"orphaned"`
+
+	// Edited lists the generated files which were edited since they were
+	// generated and are overwritten, generating them fails without Force
+	Edited []string `json:"edited"`
+}
+
+// Failure is a resource which failed to generate
+type Failure struct {
+	Resource string `json:"resource"`
+	Reason   string `json:"reason"`
+}
+
+// Dropped is a property which was left out by a filter or override
+type Dropped struct {
+	Resource string `json:"resource"`
+	Property string `json:"property"`
+}
+
+// NewReport returns an empty report
+func NewReport() *Report {
+	return &Report{
+		Succeeded:       []string{},
+		Failed:          []Failure{},
+		Skipped:         []string{},
+		Dropped:         []Dropped{},
+		SkippedPatterns: []Dropped{},
+		Pruned:          []string{},
+		Orphaned:        []string{},
+		Edited:          []string{},
+	}
+}
+
+// String returns the report as text
+func (in *Report) String() string {
+	lines := []string{
+		fmt.Sprintf("generated %v resources, %v failed, %v skipped", len(in.Succeeded), len(in.Failed), len(in.Skipped)),
+	}
+	for _, failure := range in.Failed {
+		lines = append(lines, fmt.Sprintf("  failed %v: %v", failure.Resource, failure.Reason))
+	}
+	for _, skipped := range in.Skipped {
+		lines = append(lines, fmt.Sprintf("  skipped %v", skipped))
+	}
+	for _, dropped := range in.Dropped {
+		lines = append(lines, fmt.Sprintf("  dropped %v %v", dropped.Resource, dropped.Property))
+	}
+	for _, skipped := range in.SkippedPatterns {
+		lines = append(lines, fmt.Sprintf("  skipped the pattern of %v %v, Kubernetes can't compile it", skipped.Resource, skipped.Property))
+	}
+	for _, pruned := range in.Pruned {
+		lines = append(lines, fmt.Sprintf("  pruned %v", pruned))
+	}
+	for _, orphaned := range in.Orphaned {
+		lines = append(lines, fmt.Sprintf("  orphaned %v, use --prune to delete it", orphaned))
+	}
+	for _, edited := range in.Edited {
+		lines = append(lines, fmt.Sprintf("  edited %v since it was generated, the changes are overwritten", edited))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (in *Report) succeeded(r *resource.Resource) {
+	in.Succeeded = append(in.Succeeded, reportName(r))
+	for _, property := range r.GetDroppedProperties() {
+		in.Dropped = append(in.Dropped, Dropped{Resource: reportName(r), Property: property})
+	}
+	for _, property := range r.GetSkippedPatterns() {
+		in.SkippedPatterns = append(in.SkippedPatterns, Dropped{Resource: reportName(r), Property: property})
+	}
+}
+
+func (in *Report) failed(r *resource.Resource, err error) {
+	in.Failed = append(in.Failed, Failure{Resource: reportName(r), Reason: err.Error()})
+}
+
+func (in *Report) skipped(r *resource.Resource) {
+	in.Skipped = append(in.Skipped, reportName(r))
+}
+
+func reportName(r *resource.Resource) string {
+	if r.ResourceName != "" {
+		return r.ResourceName
+	}
+	return r.Group + "." + r.Kind
+}
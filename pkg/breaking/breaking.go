@@ -0,0 +1,181 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package breaking detects changes to generated structs that break stored objects
+package breaking
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change describes a single breaking change
+type Change struct {
+	// Struct is the name of the struct which changed
+	Struct string
+
+	// Field is the JSON name of the field which changed
+	Field string
+
+	// Reason describes the change
+	Reason string
+}
+
+// String returns the change as a single line
+func (in Change) String() string {
+	if in.Field == "" {
+		return fmt.Sprintf("%v %v", in.Struct, in.Reason)
+	}
+	return fmt.Sprintf("%v.%v %v", in.Struct, in.Field, in.Reason)
+}
+
+// field is a struct field keyed by its JSON name
+type field struct {
+	name     string
+	goType   string
+	jsonName string
+}
+
+// Compare parses both Go sources and returns every struct field that was
+// removed, renamed or changed Go type or JSON name
+func Compare(existing, contents []byte) ([]Change, error) {
+	oldStructs, err := parseStructs(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	newStructs, err := parseStructs(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+
+	names := make([]string, 0, len(oldStructs))
+	for name := range oldStructs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		newFields, ok := newStructs[name]
+		if !ok {
+			changes = append(changes, Change{Struct: name, Reason: "removed"})
+			continue
+		}
+
+		for _, oldField := range oldStructs[name] {
+			newField, ok := findByJSON(newFields, oldField.jsonName)
+			if !ok {
+				if renamed, ok := findByName(newFields, oldField.name); ok {
+					changes = append(changes, Change{Struct: name, Field: oldField.jsonName, Reason: fmt.Sprintf("changed JSON name to %v", renamed.jsonName)})
+					continue
+				}
+				changes = append(changes, Change{Struct: name, Field: oldField.jsonName, Reason: "removed"})
+				continue
+			}
+
+			if newField.name != oldField.name {
+				changes = append(changes, Change{Struct: name, Field: oldField.jsonName, Reason: fmt.Sprintf("renamed from %v to %v", oldField.name, newField.name)})
+			}
+
+			if newField.goType != oldField.goType {
+				changes = append(changes, Change{Struct: name, Field: oldField.jsonName, Reason: fmt.Sprintf("changed type from %v to %v", oldField.goType, newField.goType)})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func findByJSON(fields []field, jsonName string) (field, bool) {
+	for _, f := range fields {
+		if f.jsonName == jsonName {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func findByName(fields []field, name string) (field, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// parseStructs returns the fields of every struct type in the source
+func parseStructs(src []byte) (map[string][]field, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := map[string][]field{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			fields := []field{}
+			for _, astField := range structType.Fields.List {
+				goType := types.ExprString(astField.Type)
+
+				names := []string{}
+				for _, ident := range astField.Names {
+					names = append(names, ident.Name)
+				}
+				if len(names) == 0 {
+					// embedded fields are named after their type
+					names = append(names, goType)
+				}
+
+				jsonName := ""
+				if astField.Tag != nil {
+					tag := reflect.StructTag(strings.Trim(astField.Tag.Value, "`"))
+					jsonName = strings.Split(tag.Get("json"), ",")[0]
+				}
+
+				for _, name := range names {
+					f := field{name: name, goType: goType, jsonName: jsonName}
+					if f.jsonName == "" {
+						f.jsonName = name
+					}
+					fields = append(fields, f)
+				}
+			}
+			structs[typeSpec.Name.Name] = fields
+		}
+	}
+
+	return structs, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breaking_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.awsctrl.io/generator/pkg/breaking"
+)
+
+const existing = `package v1alpha1
+
+type RepositorySpec struct {
+	metav1alpha1.CloudFormationMeta ` + "`json:\",inline\"`" + `
+
+	RepositoryName string ` + "`json:\"repositoryName,omitempty\"`" + `
+	ImageCount int ` + "`json:\"imageCount,omitempty\"`" + `
+}
+`
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []breaking.Change
+	}{
+		{"TestUnchanged", existing, []breaking.Change{}},
+		{"TestAddedFieldIsCompatible", `package v1alpha1
+
+type RepositorySpec struct {
+	metav1alpha1.CloudFormationMeta ` + "`json:\",inline\"`" + `
+
+	RepositoryName string ` + "`json:\"repositoryName\"`" + `
+	ImageCount int ` + "`json:\"imageCount,omitempty\"`" + `
+	Tags []Repository_Tag ` + "`json:\"tags,omitempty\"`" + `
+}
+`, []breaking.Change{}},
+		{"TestRemovedAndRetypedFields", `package v1alpha1
+
+type RepositorySpec struct {
+	metav1alpha1.CloudFormationMeta ` + "`json:\",inline\"`" + `
+
+	ImageCount []int ` + "`json:\"imageCount,omitempty\"`" + `
+}
+`, []breaking.Change{
+			{Struct: "RepositorySpec", Field: "repositoryName", Reason: "removed"},
+			{Struct: "RepositorySpec", Field: "imageCount", Reason: "changed type from int to []int"},
+		}},
+		{"TestRenamedJSONField", `package v1alpha1
+
+type RepositorySpec struct {
+	metav1alpha1.CloudFormationMeta ` + "`json:\",inline\"`" + `
+
+	RepositoryName string ` + "`json:\"name,omitempty\"`" + `
+	ImageCount int ` + "`json:\"imageCount,omitempty\"`" + `
+}
+`, []breaking.Change{
+			{Struct: "RepositorySpec", Field: "repositoryName", Reason: "changed JSON name to name"},
+		}},
+		{"TestRemovedStruct", `package v1alpha1
+`, []breaking.Change{
+			{Struct: "RepositorySpec", Reason: "removed"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := breaking.Compare([]byte(existing), []byte(tt.contents))
+			if err != nil {
+				t.Fatalf("breaking.Compare() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("breaking.Compare() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cfnspec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// Pin locks the specification to a ResourceSpecificationVersion and checksum
+type Pin struct {
+	// Version is the expected ResourceSpecificationVersion
+	Version string
+
+	// SHA256 is the expected checksum of the specification
+	SHA256 string
+}
+
+// IsSet returns if the pin locks down the checksum, which identifies the
+// specification with or without a version
+func (in Pin) IsSet() bool {
+	return in.SHA256 != ""
+}
+
+// Verify will return an error if the specification doesn't match the pin
+func (in Pin) Verify(body []byte) error {
+	if in.Version != "" {
+		version, err := Version(body)
+		if err != nil {
+			return err
+		}
+
+		if version != in.Version {
+			return fmt.Errorf("specification version %v does not match pinned version %v", version, in.Version)
+		}
+	}
+
+	if in.SHA256 != "" {
+		if checksum := Checksum(body); checksum != in.SHA256 {
+			return fmt.Errorf("specification sha256 %v does not match pinned sha256 %v", checksum, in.SHA256)
+		}
+	}
+
+	return nil
+}
+
+// Checksum returns the hex encoded sha256 of the specification
+func Checksum(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// Version returns the ResourceSpecificationVersion of the specification
+func Version(body []byte) (string, error) {
+	spec := struct {
+		ResourceSpecificationVersion string `json:"ResourceSpecificationVersion"`
+	}{}
+
+	if err := json.Unmarshal(body, &spec); err != nil {
+		return "", err
+	}
+	return spec.ResourceSpecificationVersion, nil
+}
+
+// CacheLoader wraps a Loader, verifying what it loads against the pin and
+// caching it by ResourceSpecificationVersion and checksum, specifications
+// without a version like the registry ones are cached by checksum
+type CacheLoader struct {
+	Fs     afero.Fs
+	Dir    string
+	Pin    Pin
+	Loader Loader
+}
+
+// NewCacheLoader will return a Loader which caches into dir, an empty dir
+// disables caching but the specification is still verified
+func NewCacheLoader(fs afero.Fs, dir string, pin Pin, loader Loader) Loader {
+	return &CacheLoader{
+		Fs:     fs,
+		Dir:    dir,
+		Pin:    pin,
+		Loader: loader,
+	}
+}
+
+// Load will read the pinned specification from the cache or the wrapped Loader
+func (in *CacheLoader) Load() ([]byte, error) {
+	if in.Dir != "" && in.Pin.IsSet() {
+		for _, path := range in.cachedPaths() {
+			body, err := afero.ReadFile(in.Fs, path)
+			// a corrupted cache entry falls through and gets reloaded
+			if err == nil && in.Pin.Verify(body) == nil {
+				return body, nil
+			}
+		}
+	}
+
+	body, err := in.Loader.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.Pin.Verify(body); err != nil {
+		return nil, err
+	}
+
+	if in.Dir == "" {
+		return body, nil
+	}
+
+	version, err := Version(body)
+	if err != nil {
+		return nil, err
+	}
+
+	path := in.path(version, Checksum(body))
+	if err := in.Fs.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	if err := afero.WriteFile(in.Fs, path, body, 0600); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// cachedPaths returns where the pinned specification could be cached, without
+// a pinned version the checksum is looked up under every version
+func (in *CacheLoader) cachedPaths() []string {
+	if in.Pin.Version != "" {
+		return []string{in.path(in.Pin.Version, in.Pin.SHA256)}
+	}
+
+	// a malformed pattern only skips the versioned entries
+	paths, _ := afero.Glob(in.Fs, in.path("*", in.Pin.SHA256))
+	return append([]string{in.path("", in.Pin.SHA256)}, paths...)
+}
+
+func (in *CacheLoader) path(version, checksum string) string {
+	return filepath.Join(in.Dir, version, checksum+".json")
+}
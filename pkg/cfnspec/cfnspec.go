@@ -18,21 +18,14 @@ package cfnspec
 
 import (
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
 	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	"go.awsctrl.io/generator/pkg/resource"
 	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
 )
 
-// var url = "https://d1uauaxba7bl26.cloudfront.net/latest/gzip/CloudFormationResourceSpecification.json"
-var url = "https://raw.githubusercontent.com/awsctrl/cfn-python-lint/master/src/cfnlint/data/CloudSpecs/us-east-2.json"
-
 type CFNSpec interface {
 	// Load will pull into the full Resource Specification
 	Load() ([]byte, error)
@@ -46,6 +39,9 @@ type CFNSpec interface {
 	// GetSpecification() will return specification
 	GetSpecification() *CloudFormationResourceSpecification
 
+	// GetChecksum will return the sha256 of the loaded specification
+	GetChecksum() string
+
 	// SetSpecification will add specification
 	SetSpecification(*CloudFormationResourceSpecification) error
 
@@ -57,16 +53,19 @@ type CFNSpec interface {
 }
 
 type cfnspec struct {
-	mux              sync.Mutex
+	mux              sync.RWMutex
 	Specification    *CloudFormationResourceSpecification
 	Resources        []resource.Resource
+	checksum         string
+	loader           Loader
 	groupIncludes    []string
 	resourceIncludes []string
 }
 
 // New will generate a new spec for parsing
-func New(groupIncludes, resourceIncludes []string) CFNSpec {
+func New(loader Loader, groupIncludes, resourceIncludes []string) CFNSpec {
 	return &cfnspec{
+		loader:           loader,
 		groupIncludes:    groupIncludes,
 		resourceIncludes: resourceIncludes,
 	}
@@ -78,6 +77,9 @@ func (in *cfnspec) Parse() error {
 	if err != nil {
 		return err
 	}
+	in.mux.Lock()
+	in.checksum = Checksum(body)
+	in.mux.Unlock()
 
 	spec := &CloudFormationResourceSpecification{}
 
@@ -96,23 +98,9 @@ func (in *cfnspec) Parse() error {
 	return nil
 }
 
-// Load will read the JSON from the endpoint
+// Load will read the JSON from the configured loader
 func (in *cfnspec) Load() (out []byte, err error) {
-	client := http.Client{
-		Timeout: time.Second * 15,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return out, err
-	}
-
-	res, err := client.Do(req)
-	if err != nil {
-		return out, err
-	}
-
-	return ioutil.ReadAll(res.Body)
+	return in.loader.Load()
 }
 
 // Properties    map[string]Property
@@ -212,15 +200,64 @@ func (in *cfnspec) GenerateResources() error {
 
 	}
 
+	// Tags are shared across all resources, generate them per resource so the
+	// CRDs don't depend on a shared type
+	tag, ok := in.GetSpecification().PropertyTypes["Tag"]
+	if !ok {
+		tag = defaultTag
+	}
+	for _, res := range resources {
+		if _, ok := res.PropertyTypes["Tag"]; ok || !usesTags(res) {
+			continue
+		}
+
+		tagtype := newBaseResource(tag)
+		props := tagtype.GetProperties()
+		for propname, prop := range tag.Properties {
+			props[propname] = newBaseProperty(prop)
+		}
+		tagtype.SetProperties(props)
+		res.PropertyTypes["Tag"] = tagtype
+	}
+
 	in.SetResources(resources)
 
 	return nil
 }
 
+// defaultTag is used when the specification doesn't include the Tag type
+var defaultTag = CloudFormationResource{
+	Documentation: "http://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-resource-tags.html",
+	Properties: map[string]Property{
+		"Key":   {PrimitiveType: "String", Required: true, UpdateType: string(resource.MutableType)},
+		"Value": {PrimitiveType: "String", Required: true, UpdateType: string(resource.MutableType)},
+	},
+}
+
+// usesTags checks if any of the resource properties is a list or map of tags
+func usesTags(res resource.Resource) bool {
+	types := []resource.ResourceType{res.ResourceType}
+	for _, propertytype := range res.PropertyTypes {
+		types = append(types, propertytype)
+	}
+
+	for _, t := range types {
+		for name, prop := range t.GetProperties() {
+			if (prop.IsList() && prop.GetItemType() == "Tag") || resource.IsMapTags(name, prop) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (in *cfnspec) GetResources() []resource.Resource {
+	in.mux.RLock()
+	defer in.mux.RUnlock()
+
 	resList := []resource.Resource{}
 	for _, res := range in.Resources {
-		if !inSlice(in.groupIncludes, res.Group) && !inSlice(in.resourceIncludes, strings.ToLower(fmt.Sprintf("%s:%s", res.Group, res.Kind))) {
+		if !included(in.groupIncludes, in.resourceIncludes, res.Group, res.Kind) {
 			continue
 		}
 
@@ -231,9 +268,17 @@ func (in *cfnspec) GetResources() []resource.Resource {
 }
 
 func (in *cfnspec) GetSpecification() *CloudFormationResourceSpecification {
+	in.mux.RLock()
+	defer in.mux.RUnlock()
 	return in.Specification
 }
 
+func (in *cfnspec) GetChecksum() string {
+	in.mux.RLock()
+	defer in.mux.RUnlock()
+	return in.checksum
+}
+
 func (in *cfnspec) SetSpecification(spec *CloudFormationResourceSpecification) error {
 	in.mux.Lock()
 	defer in.mux.Unlock()
@@ -281,6 +326,7 @@ func newBaseProperty(property Property) *resource.BaseProperty {
 		Documentation: property.Documentation,
 		Required:      property.Required,
 		UpdateType:    resource.UpdateType(property.UpdateType),
+		Validation:    property.Validation,
 	}
 
 	if property.Type != "" {
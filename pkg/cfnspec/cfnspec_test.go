@@ -17,38 +17,301 @@ limitations under the License.
 package cfnspec_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/spf13/afero"
+
 	"go.awsctrl.io/generator/pkg/cfnspec"
 	"go.awsctrl.io/generator/pkg/resource"
 )
 
+const fixture = "testdata/CloudFormationResourceSpecification.json"
+
 func Test_cfnspec_Parse(t *testing.T) {
-	spec := &cfnspec.CloudFormationResourceSpecification{}
-	resources := []resource.Resource{}
+	fs := afero.NewOsFs()
 
-	type fields struct {
-		Specification *cfnspec.CloudFormationResourceSpecification
-		Resources     []resource.Resource
-	}
 	tests := []struct {
-		name    string
-		fields  fields
-		wantErr bool
+		name          string
+		source        string
+		wantErr       bool
+		wantResources int
 	}{
-		{"TestSpecGetsAdded", fields{spec, resources}, false},
+		{"TestSpecGetsAdded", fixture, false, 2},
+		{"TestSpecDirGetsAdded", "testdata", false, 2},
+		{"TestMissingSpecErrors", "testdata/missing.json", true, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			in := cfnspec.New([]string{}, []string{})
+			loader, err := cfnspec.NewLoader(fs, tt.source, "")
+			if err != nil {
+				t.Fatalf("cfnspec.NewLoader() error = %v", err)
+			}
+
+			in := cfnspec.New(loader, []string{"ecr", "ssm"}, []string{})
 
 			if err := in.Parse(); (err != nil) != tt.wantErr {
 				t.Errorf("cfnspec.Parse() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
+			if tt.wantErr {
+				return
+			}
+
 			if in.GetSpecification().ResourceSpecificationVersion == "" {
 				t.Errorf("cfnspec.GetSpecification() ResourceSpecificationVersion %v, want not empty string", in.GetSpecification().ResourceSpecificationVersion)
 			}
+
+			if got := len(in.GetResources()); got != tt.wantResources {
+				t.Errorf("cfnspec.GetResources() = %v resources, want %v", got, tt.wantResources)
+			}
+
+			// the list tags of the repository and the map tags of the parameter
+			for _, res := range in.GetResources() {
+				if _, ok := res.PropertyTypes["Tag"]; !ok {
+					t.Errorf("cfnspec.GetResources() %v is missing the Tag property type", res.Kind)
+				}
+			}
+		})
+	}
+}
+
+func TestFileLoader_Load(t *testing.T) {
+	osfs := afero.NewOsFs()
+	body, err := afero.ReadFile(osfs, fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zipped := &bytes.Buffer{}
+	writer := gzip.NewWriter(zipped)
+	writer.Write(body)
+	writer.Close()
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "spec.json", body, 0644)
+	afero.WriteFile(fs, "spec.json.gz", zipped.Bytes(), 0644)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"TestLoadJSON", "spec.json", false},
+		{"TestLoadGzip", "spec.json.gz", false},
+		{"TestLoadMissing", "missing.json", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&cfnspec.FileLoader{Fs: fs, Path: tt.path}).Load()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileLoader.Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !bytes.Equal(got, body) {
+				t.Errorf("FileLoader.Load() didn't return the specification for %v", tt.path)
+			}
+		})
+	}
+}
+
+type failingLoader struct{}
+
+func (in *failingLoader) Load() ([]byte, error) {
+	return nil, errors.New("upstream unavailable")
+}
+
+func TestCacheLoader_Load(t *testing.T) {
+	body, err := afero.ReadFile(afero.NewOsFs(), fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := cfnspec.Checksum(body)
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "spec.json", body, 0644)
+	upstream := &cfnspec.FileLoader{Fs: fs, Path: "spec.json"}
+
+	tests := []struct {
+		name    string
+		pin     cfnspec.Pin
+		loader  cfnspec.Loader
+		wantErr bool
+	}{
+		{"TestUnpinnedLoads", cfnspec.Pin{}, upstream, false},
+		{"TestPinnedLoads", cfnspec.Pin{Version: "10.0.0", SHA256: checksum}, upstream, false},
+		{"TestPinnedLoadsFromCache", cfnspec.Pin{Version: "10.0.0", SHA256: checksum}, &failingLoader{}, false},
+		{"TestChecksumPinnedLoadsFromCache", cfnspec.Pin{SHA256: checksum}, &failingLoader{}, false},
+		{"TestVersionMismatchErrors", cfnspec.Pin{Version: "11.0.0"}, upstream, true},
+		{"TestChecksumMismatchErrors", cfnspec.Pin{Version: "10.0.0", SHA256: "abc"}, upstream, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfnspec.NewCacheLoader(fs, "cache", tt.pin, tt.loader).Load()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CacheLoader.Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !bytes.Equal(got, body) {
+				t.Errorf("CacheLoader.Load() didn't return the specification")
+			}
 		})
 	}
 }
+
+func TestCacheLoader_LoadRegistry(t *testing.T) {
+	upstream := &cfnspec.RegistryLoader{Fs: afero.NewOsFs(), Path: "testdata/registry"}
+	body, err := upstream.Load()
+	if err != nil {
+		t.Fatalf("RegistryLoader.Load() error = %v", err)
+	}
+	checksum := cfnspec.Checksum(body)
+	pin := cfnspec.Pin{SHA256: checksum}
+
+	// the registry specifications don't have a version to pin
+	fs := afero.NewMemMapFs()
+	if _, err := cfnspec.NewCacheLoader(fs, "cache", pin, upstream).Load(); err != nil {
+		t.Fatalf("CacheLoader.Load() error = %v", err)
+	}
+
+	if ok, _ := afero.Exists(fs, filepath.Join("cache", checksum+".json")); !ok {
+		t.Errorf("CacheLoader.Load() didn't cache the specification by checksum")
+	}
+
+	got, err := cfnspec.NewCacheLoader(fs, "cache", pin, &failingLoader{}).Load()
+	if err != nil {
+		t.Fatalf("CacheLoader.Load() error = %v, want the cached specification", err)
+	}
+
+	if !bytes.Equal(got, body) {
+		t.Errorf("CacheLoader.Load() didn't return the specification")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	from := &cfnspec.CloudFormationResourceSpecification{
+		ResourceSpecificationVersion: "10.0.0",
+		ResourceTypes: map[string]cfnspec.CloudFormationResource{
+			"AWS::ECR::Repository": {
+				Properties: map[string]cfnspec.Property{
+					"RepositoryName":  {PrimitiveType: "String", UpdateType: "Immutable"},
+					"LifecyclePolicy": {Type: "LifecyclePolicy", UpdateType: "Mutable"},
+					"ImageTagCount":   {PrimitiveType: "Integer", UpdateType: "Mutable"},
+				},
+			},
+			"AWS::SNS::Topic": {},
+		},
+	}
+	to := &cfnspec.CloudFormationResourceSpecification{
+		ResourceSpecificationVersion: "11.0.0",
+		ResourceTypes: map[string]cfnspec.CloudFormationResource{
+			"AWS::ECR::Repository": {
+				Properties: map[string]cfnspec.Property{
+					"RepositoryName": {PrimitiveType: "String", Required: true, UpdateType: "Mutable"},
+					"ImageTagCount":  {Type: "List", PrimitiveItemType: "Integer", UpdateType: "Mutable"},
+					"Tags":           {Type: "List", ItemType: "Tag", UpdateType: "Mutable"},
+				},
+				Attributes: map[string]cfnspec.Attribute{
+					"Arn": {PrimitiveType: "String"},
+				},
+			},
+			"AWS::ECR::Registry": {},
+		},
+	}
+
+	want := []cfnspec.Change{
+		{Type: cfnspec.ResourceAdded, Name: "AWS::ECR::Registry"},
+		{Type: cfnspec.AttributeAdded, Name: "AWS::ECR::Repository", Property: "Arn", To: "String"},
+		{Type: cfnspec.PropertyRetyped, Name: "AWS::ECR::Repository", Property: "ImageTagCount", From: "Integer", To: "List<Integer>"},
+		{Type: cfnspec.PropertyRemoved, Name: "AWS::ECR::Repository", Property: "LifecyclePolicy"},
+		{Type: cfnspec.RequiredChanged, Name: "AWS::ECR::Repository", Property: "RepositoryName", From: "false", To: "true"},
+		{Type: cfnspec.UpdateTypeChanged, Name: "AWS::ECR::Repository", Property: "RepositoryName", From: "Immutable", To: "Mutable"},
+		{Type: cfnspec.PropertyAdded, Name: "AWS::ECR::Repository", Property: "Tags", To: "List<Tag>"},
+	}
+
+	got := cfnspec.Compare(from, to, []string{"ecr"}, []string{})
+	if !reflect.DeepEqual(got.Changes, want) {
+		t.Errorf("cfnspec.Compare() = %+v, want %+v", got.Changes, want)
+	}
+}
+
+func TestRegistryLoader_Load(t *testing.T) {
+	loader, err := cfnspec.NewLoader(afero.NewOsFs(), "testdata/registry", cfnspec.RegistryFormat)
+	if err != nil {
+		t.Fatalf("cfnspec.NewLoader() error = %v", err)
+	}
+
+	in := cfnspec.New(loader, []string{"ecr", "ssm"}, []string{})
+	if err := in.Parse(); err != nil {
+		t.Fatalf("cfnspec.Parse() error = %v", err)
+	}
+
+	resources := in.GetResources()
+	if len(resources) != 1 {
+		t.Fatalf("cfnspec.GetResources() = %v resources, want 1", len(resources))
+	}
+	repository := resources[0]
+
+	properties := repository.ResourceType.GetProperties()
+	tests := []struct {
+		name           string
+		property       resource.Property
+		wantType       string
+		wantItemType   string
+		wantUpdateType resource.UpdateType
+		wantValidation *resource.Validation
+	}{
+		{"TestStringWithPattern", properties["RepositoryName"], "String", "", resource.ImmutableType, &resource.Validation{Pattern: "^(?=.{2,256}$)((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*)$", MinLength: intPtr(2), MaxLength: intPtr(256)}},
+		{"TestEnum", properties["ImageTagMutability"], "String", "", resource.MutableType, &resource.Validation{Enum: []string{"MUTABLE", "IMMUTABLE"}}},
+		{"TestMultipleTypesAreJson", properties["RepositoryPolicyText"], "Json", "", resource.MutableType, nil},
+		{"TestDefinitionIsPropertyType", properties["LifecyclePolicy"], "LifecyclePolicy", "", resource.MutableType, nil},
+		{"TestTagsUseSharedTag", properties["Tags"], "List", "Tag", resource.MutableType, &resource.Validation{MaxItems: intPtr(50)}},
+		{"TestReferencedDefinitionValidation", repository.PropertyTypes["LifecyclePolicy"].GetProperties()["RegistryId"], "String", "", resource.MutableType, &resource.Validation{Pattern: "^[0-9]{12}$", MinLength: intPtr(12), MaxLength: intPtr(12)}},
+		{"TestNestedCreateOnly", repository.PropertyTypes["Endpoint"].GetProperties()["Port"], "Integer", "", resource.ImmutableType, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.property == nil {
+				t.Fatalf("property not found")
+			}
+
+			if got := tt.property.GetType(); got != tt.wantType {
+				t.Errorf("GetType() = %v, want %v", got, tt.wantType)
+			}
+
+			if got := tt.property.GetItemType(); got != tt.wantItemType {
+				t.Errorf("GetItemType() = %v, want %v", got, tt.wantItemType)
+			}
+
+			if got := tt.property.GetUpdateType(); got != tt.wantUpdateType {
+				t.Errorf("GetUpdateType() = %v, want %v", got, tt.wantUpdateType)
+			}
+
+			if got := tt.property.GetValidation(); !reflect.DeepEqual(got, tt.wantValidation) {
+				t.Errorf("GetValidation() = %+v, want %+v", got, tt.wantValidation)
+			}
+		})
+	}
+
+	for _, name := range []string{"Arn", "RepositoryUri", "Endpoint.Address"} {
+		if _, ok := properties[name]; ok {
+			t.Errorf("read only property %v should not be a property", name)
+		}
+
+		if _, ok := repository.ResourceType.GetAttributes()[name]; !ok {
+			t.Errorf("read only property %v should be an attribute", name)
+		}
+	}
+
+	if _, ok := repository.PropertyTypes["Endpoint"].GetProperties()["Address"]; ok {
+		t.Errorf("nested read only property Endpoint.Address should not be a property")
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
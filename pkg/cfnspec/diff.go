@@ -0,0 +1,257 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cfnspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeType defines how the specification changed
+type ChangeType string
+
+const (
+	ResourceAdded       ChangeType = "ResourceAdded"
+	ResourceRemoved     ChangeType = "ResourceRemoved"
+	PropertyTypeAdded   ChangeType = "PropertyTypeAdded"
+	PropertyTypeRemoved ChangeType = "PropertyTypeRemoved"
+	PropertyAdded       ChangeType = "PropertyAdded"
+	PropertyRemoved     ChangeType = "PropertyRemoved"
+	PropertyRetyped     ChangeType = "PropertyRetyped"
+	RequiredChanged     ChangeType = "RequiredChanged"
+	UpdateTypeChanged   ChangeType = "UpdateTypeChanged"
+	AttributeAdded      ChangeType = "AttributeAdded"
+	AttributeRemoved    ChangeType = "AttributeRemoved"
+	AttributeRetyped    ChangeType = "AttributeRetyped"
+)
+
+// Change is a single difference between two specifications
+type Change struct {
+	// Type is what kind of change it is
+	Type ChangeType `json:"type"`
+
+	// Name is the resource type or property type that changed
+	Name string `json:"name"`
+
+	// Property is the property or attribute that changed
+	Property string `json:"property,omitempty"`
+
+	// From is the previous value
+	From string `json:"from,omitempty"`
+
+	// To is the new value
+	To string `json:"to,omitempty"`
+}
+
+// String returns a single line describing the change
+func (in Change) String() string {
+	name := in.Name
+	if in.Property != "" {
+		name = name + "#" + in.Property
+	}
+
+	if in.From == "" && in.To == "" {
+		return fmt.Sprintf("%-20s %v", in.Type, name)
+	}
+	return fmt.Sprintf("%-20s %v %v -> %v", in.Type, name, in.From, in.To)
+}
+
+// Diff lists all the changes between two specifications
+type Diff struct {
+	// From is the ResourceSpecificationVersion being compared from
+	From string `json:"from"`
+
+	// To is the ResourceSpecificationVersion being compared to
+	To string `json:"to"`
+
+	// Changes lists every change
+	Changes []Change `json:"changes"`
+}
+
+// String returns the diff as text, one change per line
+func (in *Diff) String() string {
+	lines := []string{fmt.Sprintf("Comparing %v to %v, %v changes", in.From, in.To, len(in.Changes))}
+	for _, change := range in.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare will list the changes between two specifications for the included groups and resources
+func Compare(from, to *CloudFormationResourceSpecification, groupIncludes, resourceIncludes []string) *Diff {
+	diff := &Diff{
+		From:    from.ResourceSpecificationVersion,
+		To:      to.ResourceSpecificationVersion,
+		Changes: []Change{},
+	}
+
+	isIncluded := func(name string) bool {
+		return includedType(groupIncludes, resourceIncludes, name)
+	}
+
+	diff.Changes = append(diff.Changes, compareTypes(from.ResourceTypes, to.ResourceTypes, ResourceAdded, ResourceRemoved, isIncluded)...)
+	diff.Changes = append(diff.Changes, compareTypes(from.PropertyTypes, to.PropertyTypes, PropertyTypeAdded, PropertyTypeRemoved, isIncluded)...)
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].Name != diff.Changes[j].Name {
+			return diff.Changes[i].Name < diff.Changes[j].Name
+		}
+		if diff.Changes[i].Property != diff.Changes[j].Property {
+			return diff.Changes[i].Property < diff.Changes[j].Property
+		}
+		return diff.Changes[i].Type < diff.Changes[j].Type
+	})
+
+	return diff
+}
+
+func compareTypes(from, to map[string]CloudFormationResource, added, removed ChangeType, isIncluded func(string) bool) []Change {
+	changes := []Change{}
+
+	for name, fromtype := range from {
+		if !isIncluded(name) {
+			continue
+		}
+
+		totype, ok := to[name]
+		if !ok {
+			changes = append(changes, Change{Type: removed, Name: name})
+			continue
+		}
+
+		changes = append(changes, compareProperties(name, fromtype.Properties, totype.Properties)...)
+		changes = append(changes, compareAttributes(name, fromtype.Attributes, totype.Attributes)...)
+	}
+
+	for name := range to {
+		if !isIncluded(name) {
+			continue
+		}
+
+		if _, ok := from[name]; !ok {
+			changes = append(changes, Change{Type: added, Name: name})
+		}
+	}
+
+	return changes
+}
+
+func compareProperties(name string, from, to map[string]Property) []Change {
+	changes := []Change{}
+
+	for propname, fromprop := range from {
+		toprop, ok := to[propname]
+		if !ok {
+			changes = append(changes, Change{Type: PropertyRemoved, Name: name, Property: propname})
+			continue
+		}
+
+		if fromtype, totype := propertyType(fromprop), propertyType(toprop); fromtype != totype {
+			changes = append(changes, Change{Type: PropertyRetyped, Name: name, Property: propname, From: fromtype, To: totype})
+		}
+
+		if fromprop.Required != toprop.Required {
+			changes = append(changes, Change{Type: RequiredChanged, Name: name, Property: propname, From: fmt.Sprint(fromprop.Required), To: fmt.Sprint(toprop.Required)})
+		}
+
+		if fromprop.UpdateType != toprop.UpdateType {
+			changes = append(changes, Change{Type: UpdateTypeChanged, Name: name, Property: propname, From: fromprop.UpdateType, To: toprop.UpdateType})
+		}
+	}
+
+	for propname, toprop := range to {
+		if _, ok := from[propname]; !ok {
+			changes = append(changes, Change{Type: PropertyAdded, Name: name, Property: propname, To: propertyType(toprop)})
+		}
+	}
+
+	return changes
+}
+
+func compareAttributes(name string, from, to map[string]Attribute) []Change {
+	changes := []Change{}
+
+	for attrname, fromattr := range from {
+		toattr, ok := to[attrname]
+		if !ok {
+			changes = append(changes, Change{Type: AttributeRemoved, Name: name, Property: attrname})
+			continue
+		}
+
+		if fromtype, totype := attributeType(fromattr), attributeType(toattr); fromtype != totype {
+			changes = append(changes, Change{Type: AttributeRetyped, Name: name, Property: attrname, From: fromtype, To: totype})
+		}
+	}
+
+	for attrname, toattr := range to {
+		if _, ok := from[attrname]; !ok {
+			changes = append(changes, Change{Type: AttributeAdded, Name: name, Property: attrname, To: attributeType(toattr)})
+		}
+	}
+
+	return changes
+}
+
+// propertyType returns the type of the property, eg. String or List<Tag>
+func propertyType(property Property) string {
+	proptype := property.Type
+	if property.PrimitiveType != "" {
+		proptype = property.PrimitiveType
+	}
+
+	itemtype := property.ItemType
+	if property.PrimitiveItemType != "" {
+		itemtype = property.PrimitiveItemType
+	}
+
+	if itemtype != "" {
+		return fmt.Sprintf("%v<%v>", proptype, itemtype)
+	}
+	return proptype
+}
+
+// attributeType returns the type of the attribute, eg. String or List<String>
+func attributeType(attribute Attribute) string {
+	attrtype := attribute.Type
+	if attribute.PrimitiveType != "" {
+		attrtype = attribute.PrimitiveType
+	}
+
+	if attribute.PrimitiveItemType != "" {
+		return fmt.Sprintf("%v<%v>", attrtype, attribute.PrimitiveItemType)
+	}
+	return attrtype
+}
+
+// includedType checks a resource type or property type name, eg. AWS::EC2::VPC
+// or AWS::EC2::VPC.Ipv6Address, against the includes, shared property types
+// like Tag are always included
+func includedType(groupIncludes, resourceIncludes []string, name string) bool {
+	resourcename := strings.Split(name, ".")[0]
+
+	nameslice := strings.Split(resourcename, "::")
+	if len(nameslice) < 3 {
+		return true
+	}
+
+	return included(groupIncludes, resourceIncludes, strings.ToLower(nameslice[1]), nameslice[len(nameslice)-1])
+}
+
+func included(groupIncludes, resourceIncludes []string, group, kind string) bool {
+	return inSlice(groupIncludes, group) || inSlice(resourceIncludes, strings.ToLower(fmt.Sprintf("%s:%s", group, kind)))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cfnspec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// DefaultSource is the specification loaded when no source is configured
+// const DefaultSource = "https://d1uauaxba7bl26.cloudfront.net/latest/gzip/CloudFormationResourceSpecification.json"
+const DefaultSource = "https://raw.githubusercontent.com/awsctrl/cfn-python-lint/master/src/cfnlint/data/CloudSpecs/us-east-2.json"
+
+const (
+	// SpecificationFormat is the CloudFormation Resource Specification format
+	SpecificationFormat = "specification"
+
+	// RegistryFormat is the CloudFormation registry resource provider schema format
+	RegistryFormat = "registry"
+)
+
+// Loader reads the raw CloudFormation Resource Specification
+type Loader interface {
+	// Load returns the specification as JSON
+	Load() ([]byte, error)
+}
+
+// NewLoader will return the Loader for the source, a source can be a URL, a
+// JSON file, a gzipped JSON file or a directory of per-service specifications,
+// registry schemas can be loaded from a file or a directory
+func NewLoader(fs afero.Fs, source, format string) (Loader, error) {
+	switch format {
+	case "", SpecificationFormat:
+	case RegistryFormat:
+		if source == "" {
+			return nil, fmt.Errorf("a source is required for the %v format", format)
+		}
+		return &RegistryLoader{Fs: fs, Path: source}, nil
+	default:
+		return nil, fmt.Errorf("unknown specification format %v", format)
+	}
+
+	if source == "" {
+		source = DefaultSource
+	}
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return &URLLoader{URL: source}, nil
+	}
+
+	if info, err := fs.Stat(source); err == nil && info.IsDir() {
+		return &DirLoader{Fs: fs, Path: source}, nil
+	}
+	return &FileLoader{Fs: fs, Path: source}, nil
+}
+
+// URLLoader loads the specification from an HTTP endpoint
+type URLLoader struct {
+	URL string
+}
+
+// Load will read the JSON from the endpoint
+func (in *URLLoader) Load() ([]byte, error) {
+	client := http.Client{
+		Timeout: time.Second * 15,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, in.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("loading specification from %v: %v", in.URL, res.Status)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decompress(body)
+}
+
+// FileLoader loads the specification from a JSON or gzipped JSON file
+type FileLoader struct {
+	Fs   afero.Fs
+	Path string
+}
+
+// Load will read the JSON from the file
+func (in *FileLoader) Load() ([]byte, error) {
+	body, err := afero.ReadFile(in.Fs, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decompress(body)
+}
+
+// DirLoader loads the specification from a directory of per-service or
+// per-resource specification files and merges them into a single document
+type DirLoader struct {
+	Fs   afero.Fs
+	Path string
+}
+
+// specFile parses both the full and the single resource specification files
+type specFile struct {
+	CloudFormationResourceSpecification
+
+	// ResourceType is used by the single resource specification files
+	ResourceType map[string]CloudFormationResource `json:"ResourceType"`
+}
+
+// Load will read and merge all the JSON files from the directory
+func (in *DirLoader) Load() ([]byte, error) {
+	infos, err := afero.ReadDir(in.Fs, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(info.Name(), ".json") || strings.HasSuffix(info.Name(), ".json.gz") {
+			names = append(names, info.Name())
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no specification files found in %v", in.Path)
+	}
+
+	spec := &CloudFormationResourceSpecification{
+		PropertyTypes: map[string]CloudFormationResource{},
+		ResourceTypes: map[string]CloudFormationResource{},
+	}
+
+	for _, name := range names {
+		body, err := (&FileLoader{Fs: in.Fs, Path: filepath.Join(in.Path, name)}).Load()
+		if err != nil {
+			return nil, err
+		}
+
+		file := &specFile{}
+		if err := json.Unmarshal(body, file); err != nil {
+			return nil, fmt.Errorf("parsing specification file %v: %v", name, err)
+		}
+
+		if file.ResourceSpecificationVersion != "" {
+			if spec.ResourceSpecificationVersion != "" && spec.ResourceSpecificationVersion != file.ResourceSpecificationVersion {
+				return nil, fmt.Errorf("specification file %v has version %v, expected %v", name, file.ResourceSpecificationVersion, spec.ResourceSpecificationVersion)
+			}
+			spec.ResourceSpecificationVersion = file.ResourceSpecificationVersion
+		}
+
+		for k, v := range file.PropertyTypes {
+			spec.PropertyTypes[k] = v
+		}
+		for k, v := range file.ResourceTypes {
+			spec.ResourceTypes[k] = v
+		}
+		for k, v := range file.ResourceType {
+			spec.ResourceTypes[k] = v
+		}
+	}
+
+	return json.Marshal(spec)
+}
+
+// decompress will gunzip the body when it is gzipped
+func decompress(body []byte) ([]byte, error) {
+	if len(body) < 2 || body[0] != 0x1f || body[1] != 0x8b {
+		return body, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,443 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cfnspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+// registrySchema parses a CloudFormation registry resource provider schema
+type registrySchema struct {
+	TypeName             string                       `json:"typeName"`
+	Description          string                       `json:"description"`
+	DocumentationURL     string                       `json:"documentationUrl"`
+	SourceURL            string                       `json:"sourceUrl"`
+	Definitions          map[string]*registryProperty `json:"definitions"`
+	Properties           map[string]*registryProperty `json:"properties"`
+	Required             []string                     `json:"required"`
+	ReadOnlyProperties   []string                     `json:"readOnlyProperties"`
+	CreateOnlyProperties []string                     `json:"createOnlyProperties"`
+}
+
+// registryProperty parses a single JSON schema property or definition
+type registryProperty struct {
+	Ref               string                       `json:"$ref"`
+	Type              interface{}                  `json:"type"`
+	Description       string                       `json:"description"`
+	Items             *registryProperty            `json:"items"`
+	Properties        map[string]*registryProperty `json:"properties"`
+	PatternProperties map[string]*registryProperty `json:"patternProperties"`
+	Required          []string                     `json:"required"`
+	Enum              []interface{}                `json:"enum"`
+	Pattern           string                       `json:"pattern"`
+	Minimum           *float64                     `json:"minimum"`
+	Maximum           *float64                     `json:"maximum"`
+	MinLength         *int                         `json:"minLength"`
+	MaxLength         *int                         `json:"maxLength"`
+	MinItems          *int                         `json:"minItems"`
+	MaxItems          *int                         `json:"maxItems"`
+	UniqueItems       bool                         `json:"uniqueItems"`
+}
+
+// RegistryLoader loads a registry resource provider schema file, or a directory
+// of them, and converts them into a single specification
+type RegistryLoader struct {
+	Fs   afero.Fs
+	Path string
+}
+
+// Load will read and convert the schemas into the specification JSON
+func (in *RegistryLoader) Load() ([]byte, error) {
+	names := []string{in.Path}
+
+	if info, err := in.Fs.Stat(in.Path); err == nil && info.IsDir() {
+		infos, err := afero.ReadDir(in.Fs, in.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		names = []string{}
+		for _, info := range infos {
+			if !info.IsDir() && (strings.HasSuffix(info.Name(), ".json") || strings.HasSuffix(info.Name(), ".json.gz")) {
+				names = append(names, filepath.Join(in.Path, info.Name()))
+			}
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no registry schemas found in %v", in.Path)
+		}
+	}
+
+	spec := &CloudFormationResourceSpecification{
+		PropertyTypes: map[string]CloudFormationResource{},
+		ResourceTypes: map[string]CloudFormationResource{},
+	}
+
+	for _, name := range names {
+		body, err := (&FileLoader{Fs: in.Fs, Path: name}).Load()
+		if err != nil {
+			return nil, err
+		}
+
+		schema := &registrySchema{}
+		if err := json.Unmarshal(body, schema); err != nil {
+			return nil, fmt.Errorf("parsing registry schema %v: %v", name, err)
+		}
+
+		if err := schema.convert(spec); err != nil {
+			return nil, fmt.Errorf("converting registry schema %v: %v", name, err)
+		}
+	}
+
+	return json.Marshal(spec)
+}
+
+// convert adds the resource type and its property types to the specification
+func (in *registrySchema) convert(spec *CloudFormationResourceSpecification) error {
+	if len(strings.Split(in.TypeName, "::")) != 3 {
+		return fmt.Errorf("invalid typeName %q", in.TypeName)
+	}
+
+	readOnly := propertyPaths(in.ReadOnlyProperties)
+	createOnly := propertyPaths(in.CreateOnlyProperties)
+
+	documentation := in.DocumentationURL
+	if documentation == "" {
+		documentation = in.SourceURL
+	}
+
+	cfnresource := CloudFormationResource{
+		Documentation: documentation,
+		Properties:    map[string]Property{},
+		Attributes:    map[string]Attribute{},
+	}
+
+	for name, prop := range in.Properties {
+		if readOnly[name] {
+			continue
+		}
+
+		property, err := in.convertProperty(spec, name, prop, inSlice(in.Required, name))
+		if err != nil {
+			return err
+		}
+
+		property.UpdateType = string(resource.MutableType)
+		if createOnly[name] {
+			property.UpdateType = string(resource.ImmutableType)
+		}
+		cfnresource.Properties[name] = property
+	}
+
+	for _, path := range in.ReadOnlyProperties {
+		segments := pathSegments(path)
+		if properties, name, ok := in.nestedProperty(spec, cfnresource.Properties, segments); ok {
+			delete(properties, name)
+		}
+
+		// paths into lists like /properties/Rules/*/Arn can't be exported
+		prop := in.lookup(segments)
+		if prop == nil {
+			continue
+		}
+		cfnresource.Attributes[strings.Join(segments, ".")] = in.convertAttribute(prop)
+	}
+
+	for _, path := range in.CreateOnlyProperties {
+		if properties, name, ok := in.nestedProperty(spec, cfnresource.Properties, pathSegments(path)); ok {
+			if property, ok := properties[name]; ok {
+				property.UpdateType = string(resource.ImmutableType)
+				properties[name] = property
+			}
+		}
+	}
+
+	spec.ResourceTypes[in.TypeName] = cfnresource
+	return nil
+}
+
+// convertProperty converts a JSON schema property, registering the property
+// types it references or defines inline
+func (in *registrySchema) convertProperty(spec *CloudFormationResourceSpecification, name string, prop *registryProperty, required bool) (Property, error) {
+	property := Property{
+		Required:      required,
+		Documentation: strings.Join(strings.Fields(prop.Description), " "),
+		Validation:    prop.validation(),
+	}
+
+	prop, defname, err := in.resolve(prop)
+	if err != nil {
+		return property, err
+	}
+	if property.Validation == nil {
+		property.Validation = prop.validation()
+	}
+	if property.Documentation == "" {
+		property.Documentation = strings.Join(strings.Fields(prop.Description), " ")
+	}
+
+	switch prop.schemaType() {
+	case "string":
+		property.PrimitiveType = "String"
+	case "integer":
+		property.PrimitiveType = "Integer"
+	case "number":
+		property.PrimitiveType = "Double"
+	case "boolean":
+		property.PrimitiveType = "Boolean"
+	case "array":
+		property.Type = "List"
+		property.DuplicatesAllowed = !prop.UniqueItems
+		if prop.Items == nil {
+			property.PrimitiveItemType = "Json"
+			break
+		}
+
+		item, err := in.convertProperty(spec, name, prop.Items, false)
+		if err != nil {
+			return property, err
+		}
+		property.ItemType, property.PrimitiveItemType = item.itemType()
+	case "object":
+		if len(prop.Properties) > 0 {
+			if defname == "" {
+				defname = name
+				if _, ok := in.Definitions[defname]; ok {
+					defname = name + "Property"
+				}
+			}
+			if err := in.convertPropertyType(spec, defname, prop); err != nil {
+				return property, err
+			}
+			property.Type = defname
+			break
+		}
+
+		if len(prop.PatternProperties) == 1 {
+			for _, value := range prop.PatternProperties {
+				item, err := in.convertProperty(spec, name, value, false)
+				if err != nil {
+					return property, err
+				}
+				property.Type = "Map"
+				property.ItemType, property.PrimitiveItemType = item.itemType()
+			}
+			break
+		}
+
+		property.PrimitiveType = "Json"
+	default:
+		property.PrimitiveType = "Json"
+	}
+
+	return property, nil
+}
+
+// convertPropertyType registers the object as a property type of the resource
+func (in *registrySchema) convertPropertyType(spec *CloudFormationResourceSpecification, name string, prop *registryProperty) error {
+	// tags use the shared Tag property type like the specification does
+	if name == "Tag" {
+		return nil
+	}
+
+	fullname := in.TypeName + "." + name
+	if _, ok := spec.PropertyTypes[fullname]; ok {
+		return nil
+	}
+
+	propertytype := CloudFormationResource{
+		Documentation: strings.Join(strings.Fields(prop.Description), " "),
+		Properties:    map[string]Property{},
+	}
+	// registering before converting the properties stops recursive definitions
+	spec.PropertyTypes[fullname] = propertytype
+
+	for propname, subprop := range prop.Properties {
+		property, err := in.convertProperty(spec, propname, subprop, inSlice(prop.Required, propname))
+		if err != nil {
+			return err
+		}
+		property.UpdateType = string(resource.MutableType)
+		propertytype.Properties[propname] = property
+	}
+
+	return nil
+}
+
+// convertAttribute converts a read only property into an attribute
+func (in *registrySchema) convertAttribute(prop *registryProperty) Attribute {
+	prop, _, _ = in.resolve(prop)
+
+	switch prop.schemaType() {
+	case "integer":
+		return Attribute{PrimitiveType: "Integer"}
+	case "number":
+		return Attribute{PrimitiveType: "Double"}
+	case "boolean":
+		return Attribute{PrimitiveType: "Boolean"}
+	case "array":
+		item := &registryProperty{Type: "string"}
+		if prop.Items != nil {
+			item, _, _ = in.resolve(prop.Items)
+		}
+		if itemtype := in.convertAttribute(item).PrimitiveType; itemtype != "Json" {
+			return Attribute{Type: "List", PrimitiveItemType: itemtype}
+		}
+		return Attribute{Type: "List", PrimitiveItemType: "String"}
+	case "string":
+		return Attribute{PrimitiveType: "String"}
+	}
+	return Attribute{PrimitiveType: "Json"}
+}
+
+// resolve follows $ref to the definition and returns it with its name
+func (in *registrySchema) resolve(prop *registryProperty) (*registryProperty, string, error) {
+	name := ""
+	for seen := 0; prop.Ref != ""; seen++ {
+		if seen > len(in.Definitions) {
+			return nil, "", fmt.Errorf("circular $ref %v", prop.Ref)
+		}
+
+		name = strings.TrimPrefix(prop.Ref, "#/definitions/")
+		def, ok := in.Definitions[name]
+		if !ok {
+			return nil, "", fmt.Errorf("definition %v not found", prop.Ref)
+		}
+		prop = def
+	}
+	return prop, name, nil
+}
+
+// nestedProperty returns the properties of the property type which has the
+// nested property of a path like ["Endpoint", "Address"] and its name, the
+// items of lists and maps are selected by *
+func (in *registrySchema) nestedProperty(spec *CloudFormationResourceSpecification, properties map[string]Property, segments []string) (map[string]Property, string, bool) {
+	if len(segments) < 2 {
+		return nil, "", false
+	}
+
+	for _, segment := range segments[:len(segments)-1] {
+		if segment == "*" {
+			continue
+		}
+
+		property, ok := properties[segment]
+		if !ok {
+			return nil, "", false
+		}
+
+		name := property.Type
+		if name == "List" || name == "Map" {
+			name = property.ItemType
+		}
+
+		propertytype, ok := spec.PropertyTypes[in.TypeName+"."+name]
+		if !ok {
+			return nil, "", false
+		}
+		properties = propertytype.Properties
+	}
+	return properties, segments[len(segments)-1], true
+}
+
+// lookup finds the property for a path like ["Endpoint", "Address"]
+func (in *registrySchema) lookup(segments []string) *registryProperty {
+	props := in.Properties
+	var prop *registryProperty
+	for _, segment := range segments {
+		next, ok := props[segment]
+		if !ok {
+			return nil
+		}
+		prop, _, _ = in.resolve(next)
+		if prop == nil {
+			return nil
+		}
+		props = prop.Properties
+	}
+	return prop
+}
+
+// schemaType returns the JSON schema type, multiple types are treated as Json
+func (in *registryProperty) schemaType() string {
+	switch t := in.Type.(type) {
+	case string:
+		return t
+	case nil:
+		if len(in.Properties) > 0 || len(in.PatternProperties) > 0 {
+			return "object"
+		}
+	}
+	return ""
+}
+
+// validation returns the constraints on the property, nil when there are none
+func (in *registryProperty) validation() *resource.Validation {
+	validation := &resource.Validation{
+		Pattern:   in.Pattern,
+		Minimum:   in.Minimum,
+		Maximum:   in.Maximum,
+		MinLength: in.MinLength,
+		MaxLength: in.MaxLength,
+		MinItems:  in.MinItems,
+		MaxItems:  in.MaxItems,
+	}
+	for _, value := range in.Enum {
+		validation.Enum = append(validation.Enum, fmt.Sprint(value))
+	}
+
+	if len(validation.Enum) == 0 && validation.Pattern == "" &&
+		validation.Minimum == nil && validation.Maximum == nil &&
+		validation.MinLength == nil && validation.MaxLength == nil &&
+		validation.MinItems == nil && validation.MaxItems == nil {
+		return nil
+	}
+	return validation
+}
+
+// itemType returns the property as the item type of a list or map
+func (in Property) itemType() (itemtype string, primitiveitemtype string) {
+	if in.PrimitiveType != "" {
+		return "", in.PrimitiveType
+	}
+	return in.Type, ""
+}
+
+// propertyPaths returns the top level property names for paths like /properties/Name
+func propertyPaths(paths []string) map[string]bool {
+	names := map[string]bool{}
+	for _, path := range paths {
+		if segments := pathSegments(path); len(segments) == 1 {
+			names[segments[0]] = true
+		}
+	}
+	return names
+}
+
+// pathSegments splits a path like /properties/Endpoint/Address
+func pathSegments(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/properties/"), "/")
+}
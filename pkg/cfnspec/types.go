@@ -16,6 +16,8 @@ limitations under the License.
 
 package cfnspec
 
+import "go.awsctrl.io/generator/pkg/resource"
+
 // CloudFormationResourceSpecification parses the root of the CFN Spec
 type CloudFormationResourceSpecification struct {
 	PropertyTypes                map[string]CloudFormationResource `json:"PropertyTypes"`
@@ -47,4 +49,7 @@ type Property struct {
 	PrimitiveType     string `json:"PrimitiveType"`
 	PrimitiveItemType string `json:"PrimitiveItemType"`
 	Type              string `json:"Type"`
+
+	// Validation is only available when converted from the registry schemas
+	Validation *resource.Validation `json:"Validation,omitempty"`
 }
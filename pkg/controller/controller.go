@@ -27,6 +27,7 @@ import (
 )
 
 var _ input.File = &Controller{}
+var _ input.Template = &Controller{}
 
 // Controller scaffolds the controllers/<group>/<resource>_controller.go
 type Controller struct {
@@ -51,6 +52,12 @@ func (in *Controller) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *Controller) ShouldOverride() bool { return true }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *Controller) TemplateName() string { return "controller" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *Controller) DefaultTemplate() string { return controllerTemplate }
+
 // Validate validates the values
 func (in *Controller) Validate() error {
 	return in.Resource.Validate()
@@ -107,6 +114,9 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(req ctrl.Request) (ctrl.Resul
 		return ctrl.Result{}, err
 	}
 
+	// +awsctrl:custom-begin:reconcile
+	// +awsctrl:custom-end:reconcile
+
 	var cfncontroller generic.Generic
 	if cfncontroller, err = generic.New(r.Client, r.Interface, r.Scheme); err != nil {
 		return ctrl.Result{}, err
@@ -127,4 +137,7 @@ func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) erro
 		Owns(&cloudformationv1alpha1.Stack{}).
 		Complete(r)
 }
+
+// +awsctrl:custom-begin:controller
+// +awsctrl:custom-end:controller
 `
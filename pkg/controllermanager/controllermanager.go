@@ -25,14 +25,12 @@ import (
 )
 
 var _ input.File = &ControllerManager{}
+var _ input.Template = &ControllerManager{}
 
 // ControllerManager scaffolds the controllers/manager/manager.go
 type ControllerManager struct {
 	input.Input
 
-	// Resource is a resource in the API group
-	Resource *resource.Resource
-
 	// Resources stores the entire list of resources
 	Resources []resource.Resource
 
@@ -61,9 +59,20 @@ func (in *ControllerManager) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *ControllerManager) ShouldOverride() bool { return true }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *ControllerManager) TemplateName() string { return "controllermanager" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *ControllerManager) DefaultTemplate() string { return managerTemplate }
+
 // Validate validates the values
 func (in *ControllerManager) Validate() error {
-	return in.Resource.Validate()
+	for _, res := range in.Resources {
+		if err := res.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 const managerTemplate = `{{ .Boilerplate }}
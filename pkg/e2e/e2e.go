@@ -26,6 +26,7 @@ import (
 )
 
 var _ input.File = &E2E{}
+var _ input.Template = &E2E{}
 
 // E2E scaffolds the e2e/group/kind_test.go
 type E2E struct {
@@ -51,6 +52,12 @@ func (in *E2E) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *E2E) ShouldOverride() bool { return false }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *E2E) TemplateName() string { return "e2e" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *E2E) DefaultTemplate() string { return e2eTemplate }
+
 const e2eTemplate = `{{ .Boilerplate }}
 
 package e2e_test
@@ -26,6 +26,7 @@ import (
 )
 
 var _ input.File = &Suite{}
+var _ input.Template = &Suite{}
 
 // Suite scaffolds the e2e/group/suite_test.go
 type Suite struct {
@@ -51,6 +52,12 @@ func (in *Suite) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *Suite) ShouldOverride() bool { return false }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *Suite) TemplateName() string { return "suite" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *Suite) DefaultTemplate() string { return suiteTemplate }
+
 const suiteTemplate = `{{ .Boilerplate }}
 
 package e2e_test
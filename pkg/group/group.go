@@ -25,6 +25,7 @@ import (
 )
 
 var _ input.File = &Group{}
+var _ input.Template = &Group{}
 
 // Group scaffolds the apis/<group>/<version>/groupversion_info.go
 type Group struct {
@@ -49,6 +50,12 @@ func (in *Group) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *Group) ShouldOverride() bool { return true }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *Group) TemplateName() string { return "groupversion" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *Group) DefaultTemplate() string { return groupTemplate }
+
 // Validate validates the values
 func (in *Group) Validate() error {
 	return in.Resource.Validate()
@@ -29,6 +29,21 @@ type File interface {
 	ShouldOverride() bool
 }
 
+// Template is implemented by files which can load their template from the templates directory
+type Template interface {
+	// TemplateName returns the name of the template, it's loaded from <name>.tmpl
+	TemplateName() string
+
+	// DefaultTemplate returns the template shipped with the generator
+	DefaultTemplate() string
+}
+
+// Compatible is implemented by files which check the existing file before it gets overridden
+type Compatible interface {
+	// CheckCompatible returns an error when the contents break the existing file
+	CheckCompatible(existing, contents []byte) error
+}
+
 // ProjectFile loads project file from kubebuilder
 type ProjectFile struct {
 	kbinput.ProjectFile
@@ -42,4 +57,31 @@ type Input struct {
 // Options is the main place for passed in options
 type Options struct {
 	kbinput.Options
+
+	// AllowBreaking will override files even when they fail the compatibility checks
+	AllowBreaking bool
+
+	// Force will overwrite the generated files which were edited since they were generated
+	Force bool
+
+	// KeepGoing will generate the remaining resources when a resource fails
+	KeepGoing bool
+
+	// Concurrency is the number of resources which are generated in parallel
+	Concurrency int
+
+	// Prune will delete the owned files which are no longer generated
+	Prune bool
+
+	// DryRun is set when the files are written to memory
+	DryRun bool
+
+	// Version is the generator version recorded in the manifest
+	Version string
+
+	// SpecVersion is the specification version recorded in the manifest
+	SpecVersion string
+
+	// TemplatesDir is the directory the templates are loaded from before the defaults
+	TemplatesDir string
 }
@@ -25,14 +25,12 @@ import (
 )
 
 var _ input.File = &CRD{}
+var _ input.Template = &CRD{}
 
 // CRD scaffolds the controllers/manager/manager.go
 type CRD struct {
 	input.Input
 
-	// Resource is a resource in the API group
-	Resource *resource.Resource
-
 	// Resources stores the entire list of resources
 	Resources []resource.Resource
 }
@@ -50,9 +48,20 @@ func (in *CRD) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *CRD) ShouldOverride() bool { return true }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *CRD) TemplateName() string { return "kustomization" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *CRD) DefaultTemplate() string { return crdTemplate }
+
 // Validate validates the values
 func (in *CRD) Validate() error {
-	return in.Resource.Validate()
+	for _, res := range in.Resources {
+		if err := res.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 const crdTemplate = `# This kustomization.yaml is not intended to be run by itself,
@@ -0,0 +1,145 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest records the files owned by the generator
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"go.awsctrl.io/generator/pkg/input"
+)
+
+// Path is where the manifest is written in the project
+const Path = ".generator-manifest.yaml"
+
+var _ input.File = &Manifest{}
+
+// Manifest scaffolds the manifest of the files owned by the generator
+type Manifest struct {
+	input.Input
+
+	// GeneratorVersion is the version of the generator
+	GeneratorVersion string
+
+	// SpecVersion is the version of the CloudFormation Resource Specification
+	SpecVersion string
+
+	// Files lists the generated files
+	Files []Entry
+}
+
+// File is deserialized into a manifest file
+type File struct {
+	GeneratorVersion string  `json:"generatorVersion"`
+	SpecVersion      string  `json:"specVersion"`
+	Files            []Entry `json:"files"`
+}
+
+// Entry is a file owned by the generator
+type Entry struct {
+	// Path is the path of the file in the project
+	Path string `json:"path"`
+
+	// Type is the input.File which generated the file
+	Type string `json:"type"`
+
+	// SHA256 is the checksum of the generated contents without the custom regions
+	SHA256 string `json:"sha256"`
+}
+
+// UnmarshalJSON reads the entry, manifests written before the hashes were
+// recorded only list the paths
+func (in *Entry) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*in = Entry{Path: path}
+		return nil
+	}
+
+	type entry Entry
+	return json.Unmarshal(data, (*entry)(in))
+}
+
+// GetInput implements input.File
+func (in *Manifest) GetInput() input.Input {
+	if in.Path == "" {
+		in.Path = Path
+	}
+
+	files := append([]Entry{}, in.Files...)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	data, _ := yaml.Marshal(&File{
+		GeneratorVersion: in.GeneratorVersion,
+		SpecVersion:      in.SpecVersion,
+		Files:            files,
+	})
+	in.TemplateBody = string(data)
+	return in.Input
+}
+
+// ShouldOverride will tell the scaffolder to override existing files
+func (in *Manifest) ShouldOverride() bool { return true }
+
+// Load reads the manifest, it's empty when the project doesn't have one
+func Load(fs afero.Fs, path string) (*File, error) {
+	file := &File{Files: []Entry{}}
+
+	exist, err := afero.Exists(fs, path)
+	if err != nil || !exist {
+		return file, err
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return file, err
+	}
+
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return file, fmt.Errorf("%v: %v", path, err)
+	}
+	return file, nil
+}
+
+// Get returns the entry of a file owned by the generator
+func (in *File) Get(path string) (Entry, bool) {
+	for _, file := range in.Files {
+		if file.Path == path {
+			return file, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Has checks if the file is owned by the generator
+func (in *File) Has(path string) bool {
+	_, ok := in.Get(path)
+	return ok
+}
+
+// Hash returns the checksum of the file contents
+func Hash(contents []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(contents))
+}
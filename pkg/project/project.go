@@ -32,9 +32,6 @@ var _ input.File = &Project{}
 type Project struct {
 	input.Input
 
-	// Resource is a resource in the API group
-	Resource *resource.Resource
-
 	// Resources stores the entire list of resources
 	Resources []resource.Resource
 
@@ -94,5 +91,10 @@ func (in *Project) ShouldOverride() bool { return true }
 
 // Validate validates the values
 func (in *Project) Validate() error {
-	return in.Resource.Validate()
+	for _, res := range in.Resources {
+		if err := res.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
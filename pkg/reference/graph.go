@@ -0,0 +1,218 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reference
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+// Edge is an ObjectReference from a property of one kind to another kind
+type Edge struct {
+	From     Kind   `json:"from"`
+	To       Kind   `json:"to"`
+	Property string `json:"property"`
+}
+
+// Graph lists the references between the generated kinds
+type Graph struct {
+	Kinds  []Kind   `json:"kinds"`
+	Edges  []Edge   `json:"edges"`
+	Cycles [][]Kind `json:"cycles"`
+}
+
+// NewGraph will build the reference graph of the resources, only the
+// references from the groups are included when groups is set
+func NewGraph(resources []resource.Resource, groups []string) *Graph {
+	index := New(resources)
+
+	graph := &Graph{Kinds: []Kind{}, Edges: []Edge{}}
+	kinds := map[Kind]bool{}
+	for i := range resources {
+		res := &resources[i]
+		if len(groups) > 0 && !inSlice(groups, res.Group) {
+			continue
+		}
+
+		from := Kind{Group: res.Group, Kind: res.Kind}
+		kinds[from] = true
+
+		for _, edge := range index.edges(res, "", res.ResourceType.GetProperties()) {
+			kinds[edge.To] = true
+			graph.Edges = append(graph.Edges, edge)
+		}
+		for name, propertytype := range res.PropertyTypes {
+			for _, edge := range index.edges(res, name, propertytype.GetProperties()) {
+				kinds[edge.To] = true
+				graph.Edges = append(graph.Edges, edge)
+			}
+		}
+	}
+
+	for kind := range kinds {
+		graph.Kinds = append(graph.Kinds, kind)
+	}
+	sortKinds(graph.Kinds)
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From.String() < b.From.String()
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		return a.To.String() < b.To.String()
+	})
+
+	graph.Cycles = graph.cycles()
+	return graph
+}
+
+// edges returns the references of the properties
+func (in *Index) edges(res *resource.Resource, parent string, props map[string]resource.Property) []Edge {
+	edges := []Edge{}
+	for name, property := range props {
+		if !res.IncludesProperty(parent, name) {
+			continue
+		}
+		if !res.IsReference(parent, name, property) && !res.IsReferenceList(parent, name, property) {
+			continue
+		}
+
+		for _, kind := range in.Resolve(res, parent, name) {
+			edges = append(edges, Edge{
+				From:     Kind{Group: res.Group, Kind: res.Kind},
+				To:       kind,
+				Property: resource.PropertyPath(parent, name),
+			})
+		}
+	}
+	return edges
+}
+
+// cycles finds the strongly connected kinds using Tarjan's algorithm
+func (in *Graph) cycles() [][]Kind {
+	adjacent := map[Kind][]Kind{}
+	selfloops := map[Kind]bool{}
+	for _, edge := range in.Edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		if edge.From == edge.To {
+			selfloops[edge.From] = true
+		}
+	}
+
+	index := 0
+	indexes := map[Kind]int{}
+	lowlinks := map[Kind]int{}
+	onstack := map[Kind]bool{}
+	stack := []Kind{}
+	cycles := [][]Kind{}
+
+	var connect func(kind Kind)
+	connect = func(kind Kind) {
+		indexes[kind] = index
+		lowlinks[kind] = index
+		index++
+		stack = append(stack, kind)
+		onstack[kind] = true
+
+		for _, next := range adjacent[kind] {
+			if _, ok := indexes[next]; !ok {
+				connect(next)
+				if lowlinks[next] < lowlinks[kind] {
+					lowlinks[kind] = lowlinks[next]
+				}
+			} else if onstack[next] && indexes[next] < lowlinks[kind] {
+				lowlinks[kind] = indexes[next]
+			}
+		}
+
+		if lowlinks[kind] != indexes[kind] {
+			return
+		}
+
+		component := []Kind{}
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onstack[last] = false
+			component = append(component, last)
+			if last == kind {
+				break
+			}
+		}
+
+		if len(component) > 1 || selfloops[kind] {
+			sortKinds(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, kind := range in.Kinds {
+		if _, ok := indexes[kind]; !ok {
+			connect(kind)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0].String() < cycles[j][0].String() })
+	return cycles
+}
+
+// Dot returns the graph in the Graphviz DOT format
+func (in *Graph) Dot() string {
+	lines := []string{"digraph references {"}
+	for _, kind := range in.Kinds {
+		lines = append(lines, fmt.Sprintf("  %q;", kind.String()))
+	}
+	for _, edge := range in.Edges {
+		lines = append(lines, fmt.Sprintf("  %q -> %q [label=%q];", edge.From.String(), edge.To.String(), edge.Property))
+	}
+	lines = append(lines, "}")
+	return strings.Join(lines, "\n")
+}
+
+// Mermaid returns the graph as a Mermaid flowchart
+func (in *Graph) Mermaid() string {
+	lines := []string{"graph LR"}
+	for _, kind := range in.Kinds {
+		lines = append(lines, fmt.Sprintf("  %v[%v]", mermaidID(kind), kind.String()))
+	}
+	for _, edge := range in.Edges {
+		lines = append(lines, fmt.Sprintf("  %v -->|%v| %v", mermaidID(edge.From), edge.Property, mermaidID(edge.To)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func mermaidID(kind Kind) string {
+	return kind.Group + "_" + kind.Kind
+}
+
+func sortKinds(kinds []Kind) {
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+}
+
+func inSlice(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
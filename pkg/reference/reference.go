@@ -0,0 +1,135 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reference indexes the kinds which ObjectReference fields can point to
+package reference
+
+import (
+	"strings"
+	"unicode"
+
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+// Kind is a group and kind which can be referenced
+type Kind struct {
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+}
+
+// String returns the kind as group.Kind
+func (in Kind) String() string {
+	return in.Group + "." + in.Kind
+}
+
+// Index maps property names to the kinds they reference
+type Index struct {
+	kinds map[string][]Kind
+}
+
+// New will index the kinds of the resources
+func New(resources []resource.Resource) *Index {
+	index := &Index{kinds: map[string][]Kind{}}
+	for _, res := range resources {
+		key := strings.ToLower(res.Kind)
+		index.kinds[key] = append(index.kinds[key], Kind{Group: res.Group, Kind: res.Kind})
+	}
+
+	for _, kinds := range index.kinds {
+		sortKinds(kinds)
+	}
+	return index
+}
+
+// Lookup returns the candidate kinds for a property name like VpcId, SubnetIds
+// or ExecutionRoleArn, the longest matching suffix wins and kinds from the
+// same group are preferred
+func (in *Index) Lookup(group, name string) []Kind {
+	name = trim(name)
+
+	words := splitWords(name)
+	for i := range words {
+		kinds, ok := in.kinds[strings.ToLower(strings.Join(words[i:], ""))]
+		if !ok {
+			continue
+		}
+
+		grouped := []Kind{}
+		for _, kind := range kinds {
+			if kind.Group == group {
+				grouped = append(grouped, kind)
+			}
+		}
+		if len(grouped) > 0 {
+			return grouped
+		}
+		return kinds
+	}
+	return []Kind{}
+}
+
+// Resolve returns the configured or inferred kinds of a property of the
+// resource, parent is the property type name and empty for the spec properties
+func (in *Index) Resolve(res *resource.Resource, parent, name string) []Kind {
+	configured := res.GetReferenceKinds(parent, name)
+	if len(configured) == 0 {
+		return in.Lookup(res.Group, name)
+	}
+
+	kinds := []Kind{}
+	for _, kind := range configured {
+		split := strings.SplitN(kind, ".", 2)
+		if len(split) != 2 {
+			continue
+		}
+		kinds = append(kinds, Kind{Group: split[0], Kind: split[1]})
+	}
+	return kinds
+}
+
+// trim removes the Id, Ids, Arn or Arns suffix
+func trim(name string) string {
+	if resource.IdsOrArns(name) {
+		return resource.TrimIdsOrArns(name)
+	}
+	if resource.IdOrArn(name) {
+		return resource.TrimIdOrArn(name)
+	}
+	return name
+}
+
+// splitWords splits a CamelCase name, acronyms like VPC stay a single word
+func splitWords(name string) []string {
+	words := []string{}
+	runes := []rune(name)
+
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		// split before an upper case letter which follows a lower case one, or
+		// which starts a new word after an acronym
+		if unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
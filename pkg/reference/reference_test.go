@@ -0,0 +1,111 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reference_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.awsctrl.io/generator/pkg/reference"
+	"go.awsctrl.io/generator/pkg/resource"
+
+	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
+)
+
+func newResource(group, kind string) resource.Resource {
+	return resource.Resource{Resource: kbresource.Resource{Group: group, Version: "v1alpha1", Kind: kind}}
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	index := reference.New([]resource.Resource{
+		newResource("ec2", "VPC"),
+		newResource("ec2", "Subnet"),
+		newResource("ec2", "SecurityGroup"),
+		newResource("iam", "Role"),
+		newResource("kms", "Key"),
+		newResource("ecs", "Cluster"),
+		newResource("eks", "Cluster"),
+	})
+
+	tests := []struct {
+		name     string
+		group    string
+		property string
+		want     []string
+	}{
+		{"TestId", "ec2", "VpcId", []string{"ec2.VPC"}},
+		{"TestIds", "ec2", "SubnetIds", []string{"ec2.Subnet"}},
+		{"TestArn", "lambda", "RoleArn", []string{"iam.Role"}},
+		{"TestSuffix", "ec2", "SourceSecurityGroupId", []string{"ec2.SecurityGroup"}},
+		{"TestPrefixedArn", "ecs", "ExecutionRoleArn", []string{"iam.Role"}},
+		{"TestSameGroupPreferred", "ecs", "ClusterArn", []string{"ecs.Cluster"}},
+		{"TestAllCandidates", "batch", "ClusterArn", []string{"ecs.Cluster", "eks.Cluster"}},
+		{"TestUnknown", "sns", "TopicArn", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, kind := range index.Lookup(tt.group, tt.property) {
+				got = append(got, kind.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Index.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	vpc := newResource("ec2", "VPC")
+	vpc.ResourceType = &resource.BaseResource{Properties: map[string]resource.Property{
+		"DefaultSubnetId": &resource.BaseProperty{Type: "String"},
+	}}
+	subnet := newResource("ec2", "Subnet")
+	subnet.ResourceType = &resource.BaseResource{Properties: map[string]resource.Property{
+		"VpcId": &resource.BaseProperty{Type: "String"},
+	}}
+	function := newResource("lambda", "Function")
+	function.ResourceType = &resource.BaseResource{Properties: map[string]resource.Property{
+		"SubnetIds": &resource.BaseProperty{Type: "List", ItemType: "String"},
+		"Handler":   &resource.BaseProperty{Type: "String"},
+	}}
+	resources := []resource.Resource{vpc, subnet, function}
+
+	tests := []struct {
+		name       string
+		groups     []string
+		wantEdges  int
+		wantCycles int
+	}{
+		{"TestAllGroups", []string{}, 3, 1},
+		{"TestFilteredGroup", []string{"lambda"}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := reference.NewGraph(resources, tt.groups)
+
+			if got := len(graph.Edges); got != tt.wantEdges {
+				t.Errorf("NewGraph() = %v edges, want %v", got, tt.wantEdges)
+			}
+
+			if got := len(graph.Cycles); got != tt.wantCycles {
+				t.Errorf("NewGraph() = %v cycles, want %v", got, tt.wantCycles)
+			}
+		})
+	}
+}
@@ -16,7 +16,15 @@ limitations under the License.
 
 package resource
 
-import "strings"
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.awsctrl.io/generator/apis/generator/v1alpha1"
+)
 
 // IdsOrArns will return if it's a list of ids or arns
 func IdsOrArns(name string) bool {
@@ -28,6 +36,186 @@ func IdOrArn(name string) bool {
 	return IsId(name, "") || IsArn(name, "")
 }
 
+// OutputName returns the stack output and field name for an attribute, nested
+// attributes like Endpoint.Address aren't valid identifiers
+func OutputName(name string) string {
+	return strings.Replace(name, ".", "", -1)
+}
+
+// IsMapTags checks if the property is a Tags property which CloudFormation takes
+// as a key value object instead of a list of tags
+func IsMapTags(name string, property Property) bool {
+	if name != "Tags" {
+		return false
+	}
+	return property.GetType() == "Json" || (property.IsMap() && property.GetItemType() == "String")
+}
+
+// IncludesAttribute checks if the attribute passes the resource filter
+func (in *Resource) IncludesAttribute(name string) bool {
+	return in.Filter.Attributes.Allowed(name)
+}
+
+// IncludesProperty checks if the property passes the resource filter and isn't
+// dropped, parent is the property type name and empty for the resource properties
+func (in *Resource) IncludesProperty(parent, name string) bool {
+	if in.getOverride(parent, name).Drop {
+		return false
+	}
+	return in.Filter.Properties.Allowed(PropertyPath(parent, name))
+}
+
+// GetDroppedProperties returns the paths of the properties which are filtered
+// or dropped by an override
+func (in *Resource) GetDroppedProperties() []string {
+	dropped := []string{}
+	for name := range in.ResourceType.GetProperties() {
+		if !in.IncludesProperty("", name) {
+			dropped = append(dropped, name)
+		}
+	}
+	for parent, propertytype := range in.PropertyTypes {
+		for name := range propertytype.GetProperties() {
+			if !in.IncludesProperty(parent, name) {
+				dropped = append(dropped, PropertyPath(parent, name))
+			}
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// GetSkippedPatterns returns the paths of the included properties whose
+// pattern Kubernetes can't compile, their values aren't checked against it
+func (in *Resource) GetSkippedPatterns() []string {
+	types := map[string]ResourceType{"": in.ResourceType}
+	for parent, propertytype := range in.PropertyTypes {
+		types[parent] = propertytype
+	}
+
+	skipped := []string{}
+	for parent, propertytype := range types {
+		for name, property := range propertytype.GetProperties() {
+			if _, ok := property.GetValidation().ForKubernetes(); !ok && in.IncludesProperty(parent, name) {
+				skipped = append(skipped, PropertyPath(parent, name))
+			}
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// lengthLookahead matches the ^(?=.{min,max}$) prefix registry patterns use to
+// limit the length of the value
+var lengthLookahead = regexp.MustCompile(`^\^\(\?=\.\{(\d*),(\d*)\}\$\)`)
+
+// ForKubernetes returns the validation with a pattern Kubernetes can compile,
+// registry patterns are ECMA regular expressions and Kubernetes uses RE2. A
+// length lookahead is turned into MinLength and MaxLength, the other patterns
+// which don't compile are left out and false is returned
+func (in *Validation) ForKubernetes() (*Validation, bool) {
+	if in == nil || in.Pattern == "" {
+		return in, true
+	}
+
+	validation := *in
+	if match := lengthLookahead.FindStringSubmatch(in.Pattern); match != nil {
+		validation.Pattern = "^" + strings.TrimPrefix(in.Pattern, match[0])
+		validation.MinLength = tighter(validation.MinLength, match[1], true)
+		validation.MaxLength = tighter(validation.MaxLength, match[2], false)
+	}
+
+	if _, err := regexp.Compile(validation.Pattern); err != nil {
+		validation.Pattern = ""
+		return &validation, false
+	}
+	return &validation, true
+}
+
+// tighter returns the tighter of the length and the bound of the lookahead
+func tighter(length *int, bound string, min bool) *int {
+	value, err := strconv.Atoi(bound)
+	if err != nil {
+		return length
+	}
+	if length == nil || (min && value > *length) || (!min && value < *length) {
+		return &value
+	}
+	return length
+}
+
+// IsReference checks if the property is generated as an ObjectReference
+func (in *Resource) IsReference(parent, name string, property Property) bool {
+	if property.GetType() != "String" {
+		return false
+	}
+	if reference := in.getOverride(parent, name).Reference; reference != nil {
+		return *reference
+	}
+	return IdOrArn(name)
+}
+
+// IsReferenceList checks if the property is generated as a list of ObjectReferences
+func (in *Resource) IsReferenceList(parent, name string, property Property) bool {
+	if !property.IsList() || property.GetItemType() != "String" {
+		return false
+	}
+	if reference := in.getOverride(parent, name).Reference; reference != nil {
+		return *reference
+	}
+	return IdsOrArns(name)
+}
+
+// GetReferenceKinds returns the configured group.Kind candidates of a reference
+func (in *Resource) GetReferenceKinds(parent, name string) []string {
+	return in.getOverride(parent, name).Kinds
+}
+
+// GetFieldName returns the Go field name of the property
+func (in *Resource) GetFieldName(parent, name string, property Property) string {
+	if override := in.getOverride(parent, name).Name; override != "" {
+		return override
+	}
+	if in.IsReference(parent, name, property) {
+		return TrimIdOrArn(name) + "Ref"
+	}
+	if in.IsReferenceList(parent, name, property) {
+		return TrimIdsOrArns(name) + "Refs"
+	}
+	return name
+}
+
+// GetJSONName returns the JSON name of the property
+func (in *Resource) GetJSONName(parent, name string, property Property) string {
+	if override := in.getOverride(parent, name).JSONName; override != "" {
+		return override
+	}
+	fieldname := []rune(in.GetFieldName(parent, name, property))
+	fieldname[0] = unicode.ToLower(fieldname[0])
+	return string(fieldname)
+}
+
+// IsRequired checks if the property is required
+func (in *Resource) IsRequired(parent, name string, property Property) bool {
+	if required := in.getOverride(parent, name).Required; required != nil {
+		return *required
+	}
+	return property.GetRequired()
+}
+
+func (in *Resource) getOverride(parent, name string) v1alpha1.PropertyOverride {
+	return in.Override.Properties[PropertyPath(parent, name)]
+}
+
+// PropertyPath returns the name used by filters and overrides, properties of
+// property types are named <PropertyType>.<Property>
+func PropertyPath(parent, name string) string {
+	if parent != "" {
+		return parent + "." + name
+	}
+	return name
+}
+
 // IsArn checks if it's an Id
 func IsId(name, postfix string) bool {
 	return strings.HasSuffix(strings.ToLower(name), strings.ToLower("Id"+postfix))
@@ -65,21 +253,26 @@ func (in *BaseAttribute) GetType() string {
 	if in.Type != "" {
 		return in.Type
 	}
-	if in.PrimitiveType != "" {
-		return in.Type
-	}
-	return ""
+	return in.PrimitiveType
 }
 
 // GetItemType return the type
 func (in *BaseAttribute) GetItemType() string {
-	if in.Type != "" {
-		return in.Type
-	}
-	if in.PrimitiveType != "" {
-		return in.Type
+	return in.PrimitiveItemType
+}
+
+// GetOutputType returns the type for the <Kind>Output fields, list attributes
+// are joined by the stack outputs and split when they are read
+func (in *BaseAttribute) GetOutputType() string {
+	switch in.GetType() {
+	case "List":
+		return "[]string"
+	case "Integer":
+		return "int"
+	case "Long":
+		return "int64"
 	}
-	return ""
+	return "string"
 }
 
 // GetDocumentation returns the documentation link
@@ -89,6 +282,8 @@ func (in *BaseResource) GetDocumentation() string {
 
 // GetProperties returns the properties
 func (in *BaseResource) GetProperties() map[string]Property {
+	in.mux.RLock()
+	defer in.mux.RUnlock()
 	return in.Properties
 }
 
@@ -101,6 +296,8 @@ func (in *BaseResource) SetProperties(props map[string]Property) {
 
 // GetAttributes returns the attrs
 func (in *BaseResource) GetAttributes() map[string]Attribute {
+	in.mux.RLock()
+	defer in.mux.RUnlock()
 	return in.Attributes
 }
 
@@ -138,6 +335,8 @@ func (in *BaseProperty) IsParameter() bool {
 		return true
 	case "Integer":
 		return true
+	case "Long":
+		return true
 	case "Timestamp":
 		return true
 	case "Double":
@@ -160,8 +359,12 @@ func (in *BaseProperty) IsListParameter() bool {
 		return true
 	case "Integer":
 		return true
+	case "Long":
+		return true
 	case "Double":
 		return true
+	case "Timestamp":
+		return true
 	}
 	return false
 }
@@ -200,56 +403,55 @@ func (in *BaseProperty) GetSingularGoType(kind string) string {
 func (in *BaseProperty) ConstructGoType(kind string, plural string) string {
 	switch in.Type {
 	case "Json":
-		return "string"
+		return "*runtime.RawExtension"
 	case "String":
 		return "string"
 	case "Integer":
 		return "int"
+	case "Long":
+		return "int64"
 	case "Double":
-		return "int"
-	case "Timestamp":
+		// floats aren't allowed in CRDs so decimals are encoded as strings
 		return "string"
+	case "Timestamp":
+		return "metav1.Time"
 	case "Boolean":
 		return "bool"
 	case "Map":
-		var itemtype string
-		switch in.ItemType {
-		case "String":
-			itemtype = "string"
-		case "Boolean":
-			itemtype = "bool"
-		default:
-			itemtype = kind + "_" + in.ItemType
-		}
-
 		base := ""
 		if plural == "[]" {
 			base = "map[string]"
 		}
-		return base + itemtype
+		return base + in.constructItemGoType(kind)
 
 	case "List":
-		var itemtype string
-		switch in.ItemType {
-		case "Tag":
-			itemtype = "metav1alpha1.Tag"
-		case "String":
-			itemtype = "string"
-		case "Integer":
-			itemtype = "int"
-		case "Double":
-			return "int"
-		case "Boolean":
-			itemtype = "bool"
-		default:
-			itemtype = kind + "_" + in.ItemType
-		}
-
-		return plural + itemtype
+		return plural + in.constructItemGoType(kind)
 	}
 	return kind + "_" + in.Type
 }
 
+// constructItemGoType will return the type for the items of a list or map
+func (in *BaseProperty) constructItemGoType(kind string) string {
+	switch in.ItemType {
+	case "Json":
+		return "runtime.RawExtension"
+	case "String":
+		return "string"
+	case "Integer":
+		return "int"
+	case "Long":
+		return "int64"
+	case "Double":
+		// floats aren't allowed in CRDs so decimals are encoded as strings
+		return "string"
+	case "Timestamp":
+		return "metav1.Time"
+	case "Boolean":
+		return "bool"
+	}
+	return kind + "_" + in.ItemType
+}
+
 // GetRequired returns if the property is required
 func (in *BaseProperty) GetRequired() bool {
 	return in.Required
@@ -264,3 +466,8 @@ func (in *BaseProperty) GetUpdateType() UpdateType {
 func (in *BaseProperty) GetItemType() string {
 	return in.ItemType
 }
+
+// GetValidation returns the value constraints
+func (in *BaseProperty) GetValidation() *Validation {
+	return in.Validation
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.awsctrl.io/generator/pkg/resource"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestValidation_ForKubernetes(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation *resource.Validation
+		want       *resource.Validation
+		wantOk     bool
+	}{
+		{"TestNil", nil, nil, true},
+		{"TestCompiles", &resource.Validation{Pattern: "^[0-9]{12}$"}, &resource.Validation{Pattern: "^[0-9]{12}$"}, true},
+		{"TestLengthLookahead", &resource.Validation{Pattern: "^(?=.{2,256}$)[a-z]+$"}, &resource.Validation{Pattern: "^[a-z]+$", MinLength: intPtr(2), MaxLength: intPtr(256)}, true},
+		{"TestTighterLength", &resource.Validation{Pattern: "^(?=.{2,256}$)[a-z]+$", MinLength: intPtr(3), MaxLength: intPtr(300)}, &resource.Validation{Pattern: "^[a-z]+$", MinLength: intPtr(3), MaxLength: intPtr(256)}, true},
+		{"TestNegativeLookahead", &resource.Validation{Pattern: "^(?!aws:).*$", MaxLength: intPtr(128)}, &resource.Validation{MaxLength: intPtr(128)}, false},
+		{"TestBackreference", &resource.Validation{Pattern: `^(a)\1$`}, &resource.Validation{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.validation.ForKubernetes()
+			if ok != tt.wantOk {
+				t.Errorf("Validation.ForKubernetes() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validation.ForKubernetes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_GetSkippedPatterns(t *testing.T) {
+	r := &resource.Resource{
+		ResourceType: &resource.BaseResource{
+			Properties: map[string]resource.Property{
+				"Name": &resource.BaseProperty{Type: "String", Validation: &resource.Validation{Pattern: "^(?!aws:).*$"}},
+				"Arn":  &resource.BaseProperty{Type: "String", Validation: &resource.Validation{Pattern: "^arn:.*$"}},
+			},
+		},
+		PropertyTypes: map[string]resource.ResourceType{
+			"Rule": &resource.BaseResource{
+				Properties: map[string]resource.Property{
+					"Prefix": &resource.BaseProperty{Type: "String", Validation: &resource.Validation{Pattern: "^(?<=a)b$"}},
+				},
+			},
+		},
+	}
+
+	if got, want := r.GetSkippedPatterns(), []string{"Name", "Rule.Prefix"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Resource.GetSkippedPatterns() = %v, want %v", got, want)
+	}
+}
+
+func TestBaseProperty_GetGoType(t *testing.T) {
+	tests := []struct {
+		name         string
+		property     *resource.BaseProperty
+		want         string
+		wantSingular string
+	}{
+		{"TestJson", &resource.BaseProperty{Type: "Json"}, "*runtime.RawExtension", "*runtime.RawExtension"},
+		{"TestListJson", &resource.BaseProperty{Type: "List", ItemType: "Json"}, "[]runtime.RawExtension", "runtime.RawExtension"},
+		{"TestListLong", &resource.BaseProperty{Type: "List", ItemType: "Long"}, "[]int64", "int64"},
+		{"TestListType", &resource.BaseProperty{Type: "List", ItemType: "Rule"}, "[]Repository_Rule", "Repository_Rule"},
+		{"TestMapJson", &resource.BaseProperty{Type: "Map", ItemType: "Json"}, "map[string]runtime.RawExtension", "runtime.RawExtension"},
+		{"TestMapString", &resource.BaseProperty{Type: "Map", ItemType: "String"}, "map[string]string", "string"},
+		{"TestMapInteger", &resource.BaseProperty{Type: "Map", ItemType: "Integer"}, "map[string]int", "int"},
+		{"TestMapLong", &resource.BaseProperty{Type: "Map", ItemType: "Long"}, "map[string]int64", "int64"},
+		{"TestMapDouble", &resource.BaseProperty{Type: "Map", ItemType: "Double"}, "map[string]string", "string"},
+		{"TestMapTimestamp", &resource.BaseProperty{Type: "Map", ItemType: "Timestamp"}, "map[string]metav1.Time", "metav1.Time"},
+		{"TestMapType", &resource.BaseProperty{Type: "Map", ItemType: "Rule"}, "map[string]Repository_Rule", "Repository_Rule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.property.GetGoType("Repository"); got != tt.want {
+				t.Errorf("BaseProperty.GetGoType() = %v, want %v", got, tt.want)
+			}
+			if got := tt.property.GetSingularGoType("Repository"); got != tt.wantSingular {
+				t.Errorf("BaseProperty.GetSingularGoType() = %v, want %v", got, tt.wantSingular)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package resource
 import (
 	"sync"
 
+	"go.awsctrl.io/generator/apis/generator/v1alpha1"
+
 	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
 )
 
@@ -34,6 +36,12 @@ type Resource struct {
 
 	// PropertyTypes lists types of properties
 	PropertyTypes map[string]ResourceType
+
+	// Filter includes or excludes attributes and properties
+	Filter v1alpha1.Filter
+
+	// Override changes how the properties are generated
+	Override v1alpha1.Override
 }
 
 // ResourceType sets up all the attributes
@@ -58,8 +66,9 @@ type ResourceType interface {
 type UpdateType string
 
 const (
-	MutableType   UpdateType = "Mutable"
-	ImmutableType UpdateType = "Immutable"
+	MutableType     UpdateType = "Mutable"
+	ImmutableType   UpdateType = "Immutable"
+	ConditionalType UpdateType = "Conditional"
 )
 
 // Attribute defines the attribute functions
@@ -69,6 +78,9 @@ type Attribute interface {
 
 	// GetItemType returns an item type if its a list or map
 	GetItemType() string
+
+	// GetOutputType returns the go type of the stack output
+	GetOutputType() string
 }
 
 // Property returns the property functions
@@ -108,11 +120,26 @@ type Property interface {
 
 	// GetItemType returns an item type if its a list or map
 	GetItemType() string
+
+	// GetValidation returns the value constraints, nil when there are none
+	GetValidation() *Validation
+}
+
+// Validation contains the constraints for a property value
+type Validation struct {
+	Enum      []string `json:"Enum,omitempty"`
+	Pattern   string   `json:"Pattern,omitempty"`
+	Minimum   *float64 `json:"Minimum,omitempty"`
+	Maximum   *float64 `json:"Maximum,omitempty"`
+	MinLength *int     `json:"MinLength,omitempty"`
+	MaxLength *int     `json:"MaxLength,omitempty"`
+	MinItems  *int     `json:"MinItems,omitempty"`
+	MaxItems  *int     `json:"MaxItems,omitempty"`
 }
 
 // BaseResource contains the resource objects
 type BaseResource struct {
-	mux           sync.Mutex
+	mux           sync.RWMutex
 	Documentation string
 	Attributes    map[string]Attribute
 	Properties    map[string]Property
@@ -120,12 +147,12 @@ type BaseResource struct {
 
 // BaseProperty contain the attributes for a resource
 type BaseProperty struct {
-	mux           sync.Mutex
 	Documentation string
 	Required      bool
 	Type          string
 	UpdateType    UpdateType
 	ItemType      string
+	Validation    *Validation
 }
 
 // BaseAttribute contains the attributes for attributes
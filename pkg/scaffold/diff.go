@@ -0,0 +1,163 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes in a hunk
+const diffContext = 3
+
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// unifiedDiff returns the unified diff between a and b
+func unifiedDiff(from, to string, a, b []byte) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	// line numbers of each diff line in a and b
+	astart, bstart := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	astart[0], bstart[0] = 1, 1
+	for i, line := range lines {
+		astart[i+1], bstart[i+1] = astart[i], bstart[i]
+		if line.kind != '+' {
+			astart[i+1]++
+		}
+		if line.kind != '-' {
+			bstart[i+1]++
+		}
+	}
+
+	out := &strings.Builder{}
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// merge the changes which are close enough to share their context
+		end := i
+		for j := i; j < len(lines); j++ {
+			if lines[j].kind != ' ' {
+				end = j
+			} else if j-end > 2*diffContext {
+				break
+			}
+		}
+		start, stop := max(i-diffContext, 0), min(end+diffContext+1, len(lines))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(out, "--- %v\n+++ %v\n", from, to)
+		}
+		fmt.Fprintf(out, "@@ -%v +%v @@\n",
+			hunkRange(astart[start], astart[stop]-astart[start]),
+			hunkRange(bstart[start], bstart[stop]-bstart[start]))
+		for _, line := range lines[start:stop] {
+			fmt.Fprintf(out, "%c%v\n", line.kind, line.text)
+		}
+		i = stop
+	}
+	return out.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	return fmt.Sprintf("%v,%v", start, count)
+}
+
+// diffLines returns the lines of a and b marked as kept, removed or added
+// using the longest common subsequence of the lines which differ
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := []diffLine{}
+	for _, line := range a[:prefix] {
+		lines = append(lines, diffLine{' ', line})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	lcs := make([][]int32, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			lines = append(lines, diffLine{' ', ma[i]})
+			i++
+			j++
+		case j == len(mb) || (i < len(ma) && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', ma[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', mb[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	return lines
+}
+
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
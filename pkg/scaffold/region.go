@@ -0,0 +1,138 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	// regionBegin starts a custom region, it's followed by the region name
+	regionBegin = "+awsctrl:custom-begin:"
+
+	// regionEnd ends a custom region, it's followed by the region name
+	regionEnd = "+awsctrl:custom-end:"
+)
+
+// parseRegions returns the contents of the custom regions by name
+func parseRegions(contents []byte) (map[string][]string, error) {
+	regions := map[string][]string{}
+
+	name := ""
+	for i, line := range splitLines(contents) {
+		if begin, ok := regionName(line, regionBegin); ok {
+			if name != "" {
+				return nil, fmt.Errorf("line %v: custom region %v starts inside custom region %v", i+1, begin, name)
+			}
+			if _, ok := regions[begin]; ok {
+				return nil, fmt.Errorf("line %v: custom region %v is repeated", i+1, begin)
+			}
+			name = begin
+			regions[name] = []string{}
+			continue
+		}
+
+		if end, ok := regionName(line, regionEnd); ok {
+			if end != name {
+				return nil, fmt.Errorf("line %v: custom region %v ends without starting", i+1, end)
+			}
+			name = ""
+			continue
+		}
+
+		if name != "" {
+			regions[name] = append(regions[name], line)
+		}
+	}
+
+	if name != "" {
+		return nil, fmt.Errorf("custom region %v doesn't end", name)
+	}
+	return regions, nil
+}
+
+// mergeRegions replaces the custom regions of the generated contents with the
+// regions of the existing file, a region of the existing file which isn't
+// generated anymore is an error because its contents would be lost
+func mergeRegions(existing, contents []byte) ([]byte, error) {
+	existingRegions, err := parseRegions(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := parseRegions(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range existingRegions {
+		if _, ok := regions[name]; !ok {
+			return nil, fmt.Errorf("custom region %v was removed, move its contents before generating the file", name)
+		}
+	}
+
+	return replaceRegions(contents, existingRegions), nil
+}
+
+// StripRegions returns the contents with empty custom regions so the generated
+// parts of files can be compared
+func StripRegions(contents []byte) []byte {
+	return replaceRegions(contents, map[string][]string{})
+}
+
+func replaceRegions(contents []byte, regions map[string][]string) []byte {
+	if !bytes.Contains(contents, []byte(regionBegin)) {
+		return contents
+	}
+
+	lines := []string{}
+
+	inside := false
+	for _, line := range splitLines(contents) {
+		if name, ok := regionName(line, regionBegin); ok {
+			lines = append(lines, line)
+			lines = append(lines, regions[name]...)
+			inside = true
+			continue
+		}
+
+		if _, ok := regionName(line, regionEnd); ok {
+			inside = false
+		}
+
+		if !inside {
+			lines = append(lines, line)
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// regionName returns the name of the region when the line has the marker
+func regionName(line, marker string) (string, bool) {
+	i := strings.Index(line, marker)
+	if i < 0 {
+		return "", false
+	}
+	fields := strings.Fields(line[i+len(marker):])
+	if len(fields) == 0 {
+		return "", false
+	}
+	return fields[0], true
+}
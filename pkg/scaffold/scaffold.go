@@ -18,7 +18,10 @@ package scaffold
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"unicode"
 
 	"html/template"
@@ -26,6 +29,7 @@ import (
 
 	"github.com/spf13/afero"
 	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/manifest"
 	"golang.org/x/tools/imports"
 
 	"github.com/Masterminds/sprig"
@@ -35,30 +39,129 @@ import (
 // Scaffold contains the functions for generating files
 type Scaffold struct {
 	fs afero.Fs
+
+	// options contains CLI params
+	options input.Options
+
+	// manifest has the hashes of the files when they were generated
+	manifest *manifest.File
+
+	// changes records what happened to each file
+	changes []Change
+}
+
+// Action is what happened to a generated file
+type Action string
+
+const (
+	CreateAction    Action = "create"
+	OverwriteAction Action = "overwrite"
+	UnchangedAction Action = "unchanged"
+	SkipAction      Action = "skip"
+	DeleteAction    Action = "delete"
+)
+
+// Change records what happened to a generated file
+type Change struct {
+	Path   string
+	Action Action
+
+	// Type is the input.File which generated the file
+	Type string
+
+	// Existing is the file before it was overwritten or deleted
+	Existing []byte
+
+	// Contents is the generated file, it's empty when the file is skipped
+	Contents []byte
+
+	// Edited is set when the existing file was edited since it was generated
+	Edited bool
+}
+
+// Diff returns the unified diff of the change, it's empty when the file isn't
+// created, overwritten or deleted
+func (in Change) Diff() string {
+	switch in.Action {
+	case CreateAction:
+		return unifiedDiff("/dev/null", "b/"+in.Path, nil, in.Contents)
+	case OverwriteAction:
+		return unifiedDiff("a/"+in.Path, "b/"+in.Path, in.Existing, in.Contents)
+	case DeleteAction:
+		return unifiedDiff("a/"+in.Path, "/dev/null", in.Existing, nil)
+	}
+	return ""
 }
 
 // New initializes the scaffolder
-func New(fs afero.Fs) *Scaffold {
+func New(fs afero.Fs, options input.Options) *Scaffold {
 	return &Scaffold{
-		fs: fs,
+		fs:      fs,
+		options: options,
 	}
 }
 
-// Execute will generate the file
+// SetManifest sets the manifest of the previous run, the files which were
+// edited since they were generated are only overwritten with Force
+func (s *Scaffold) SetManifest(m *manifest.File) {
+	s.manifest = m
+}
+
+// Execute will generate the files, all the files are rendered before any is
+// written so a failing file doesn't leave the others half generated
 func (s *Scaffold) Execute(files ...input.File) error {
 	afs := afero.Afero{
 		Fs: s.fs,
 	}
 
-	for _, file := range files {
+	rendered := make([][]byte, len(files))
+	edited := make([]bool, len(files))
+	for i, file := range files {
 		fileinput := file.GetInput()
 		path := fileinput.Path
 
-		contents, err := s.doTemplate(fileinput, file)
+		exist, err := afero.Exists(s.fs, path)
 		if err != nil {
 			return err
 		}
 
+		// existing files which aren't overridden are never written
+		if file.ShouldOverride() == false && exist {
+			continue
+		}
+
+		if fileinput.TemplateBody, err = s.getTemplate(file, fileinput.TemplateBody); err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+
+		contents, err := s.doTemplate(fileinput, file)
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+
+		if file.ShouldOverride() == true && exist {
+			if contents, err = s.mergeRegions(path, contents); err != nil {
+				return fmt.Errorf("%v: %v", path, err)
+			}
+			if edited[i], err = s.checkEdited(path, contents); err != nil {
+				return err
+			}
+		}
+
+		if file.ShouldOverride() == true {
+			if err := s.checkCompatible(file, path, exist, contents); err != nil {
+				return err
+			}
+		}
+
+		rendered[i] = contents
+	}
+
+	changes := []Change{}
+	for i, file := range files {
+		path := file.GetInput().Path
+		change := Change{Path: path, Action: CreateAction, Type: fileType(file), Contents: rendered[i], Edited: edited[i]}
+
 		dir := filepath.Dir(path)
 		if err := afs.MkdirAll(dir, 0700); err != nil {
 			return err
@@ -69,17 +172,161 @@ func (s *Scaffold) Execute(files ...input.File) error {
 			return err
 		}
 
-		if file.ShouldOverride() == true {
-			if err := afs.WriteFile(path, contents, 0600); err != nil {
+		if exist && file.ShouldOverride() == false {
+			change.Action = SkipAction
+			changes = append(changes, change)
+			continue
+		}
+
+		if exist {
+			if change.Existing, err = afs.ReadFile(path); err != nil {
 				return err
 			}
+			change.Action = OverwriteAction
+			if bytes.Equal(change.Existing, change.Contents) {
+				change.Action = UnchangedAction
+			}
+		}
+
+		if change.Action != UnchangedAction {
+			if err := afs.WriteFile(path, change.Contents, 0600); err != nil {
+				return err
+			}
+		}
+		changes = append(changes, change)
+	}
+	s.changes = append(s.changes, changes...)
+
+	return nil
+}
+
+// Delete removes the files, the files which don't exist are ignored and in a
+// dry run the files are only recorded because the read only tree can't be changed
+func (s *Scaffold) Delete(paths ...string) error {
+	for _, path := range paths {
+		existing, err := afero.ReadFile(s.fs, path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
 		}
 
-		if file.ShouldOverride() == false && !exist {
-			if err := afs.WriteFile(path, contents, 0600); err != nil {
+		if !s.options.DryRun {
+			if err := s.fs.Remove(path); err != nil {
+				return err
+			}
+			if err := s.removeEmptyDirs(filepath.Dir(path)); err != nil {
 				return err
 			}
 		}
+		s.changes = append(s.changes, Change{Path: path, Action: DeleteAction, Existing: existing})
+	}
+	return nil
+}
+
+// removeEmptyDirs removes the directory and its parents while they're empty
+func (s *Scaffold) removeEmptyDirs(dir string) error {
+	for ; dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		infos, err := afero.ReadDir(s.fs, dir)
+		if err != nil || len(infos) > 0 {
+			return err
+		}
+		if err := s.fs.Remove(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetChanges returns what happened to the files generated by Execute
+func (s *Scaffold) GetChanges() []Change {
+	return s.changes
+}
+
+// getTemplate returns the template from the templates directory when it has
+// the template of the file, otherwise the default template
+func (s *Scaffold) getTemplate(file input.File, body string) (string, error) {
+	template, ok := file.(input.Template)
+	if !ok || s.options.TemplatesDir == "" {
+		return body, nil
+	}
+
+	path := filepath.Join(s.options.TemplatesDir, TemplateFile(template))
+	exist, err := afero.Exists(s.fs, path)
+	if err != nil || !exist {
+		return body, err
+	}
+
+	contents, err := afero.ReadFile(s.fs, path)
+	return string(contents), err
+}
+
+// TemplateFile returns the file name of the template in the templates directory
+func TemplateFile(template input.Template) string {
+	return template.TemplateName() + ".tmpl"
+}
+
+// mergeRegions keeps the custom regions of the existing file, the imports of
+// go files are processed again for the code in the regions
+func (s *Scaffold) mergeRegions(path string, contents []byte) ([]byte, error) {
+	existing, err := afero.ReadFile(s.fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergeRegions(existing, contents)
+	if err != nil || bytes.Equal(merged, contents) {
+		return merged, err
+	}
+
+	if filepath.Ext(path) == ".go" {
+		return imports.Process(path, merged, nil)
+	}
+	return merged, nil
+}
+
+// checkEdited checks the existing file against its hash in the manifest outside
+// of the custom regions, overwriting an edited file fails unless it's forced or
+// the files aren't written
+func (s *Scaffold) checkEdited(path string, contents []byte) (bool, error) {
+	if s.manifest == nil {
+		return false, nil
+	}
+
+	entry, ok := s.manifest.Get(path)
+	if !ok || entry.SHA256 == "" {
+		return false, nil
+	}
+
+	existing, err := afero.ReadFile(s.fs, path)
+	if err != nil {
+		return false, err
+	}
+
+	if entry.SHA256 == manifest.Hash(StripRegions(existing)) {
+		return false, nil
+	}
+
+	if !bytes.Equal(existing, contents) && !s.options.Force && !s.options.DryRun {
+		return true, fmt.Errorf("%v: edited since it was generated, use --force to overwrite it", path)
+	}
+	return true, nil
+}
+
+func (s *Scaffold) checkCompatible(file input.File, path string, exist bool, contents []byte) error {
+	compatible, ok := file.(input.Compatible)
+	if !ok || !exist || s.options.AllowBreaking {
+		return nil
+	}
+
+	existing, err := afero.ReadFile(s.fs, path)
+	if err != nil {
+		return err
+	}
+
+	if err := compatible.CheckCompatible(existing, contents); err != nil {
+		return fmt.Errorf("%v: %v, use --allow-breaking to override it", path, err)
 	}
 
 	return nil
@@ -94,6 +341,10 @@ func (s *Scaffold) doTemplate(i input.Input, e input.File) ([]byte, error) {
 	out := &bytes.Buffer{}
 	err = temp.Execute(out, e)
 	if err != nil {
+		// template errors wrap the errors returned by the template functions
+		for errors.Unwrap(err) != nil {
+			err = errors.Unwrap(err)
+		}
 		return nil, err
 	}
 	b := out.Bytes()
@@ -109,6 +360,11 @@ func (s *Scaffold) doTemplate(i input.Input, e input.File) ([]byte, error) {
 	return b, nil
 }
 
+// fileType returns the name of the input.File type like types.Types
+func fileType(file input.File) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", file), "*")
+}
+
 func newTemplate(t input.File) *template.Template {
 	return template.New(fmt.Sprintf("%T", t)).Funcs(sprig.FuncMap()).Funcs(funcMap())
 }
@@ -0,0 +1,165 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/scaffold"
+)
+
+type file struct {
+	input.Input
+	override bool
+}
+
+func (in *file) GetInput() input.Input { return in.Input }
+
+func (in *file) ShouldOverride() bool { return in.override }
+
+func newFile(path, body string, override bool) *file {
+	f := &file{override: override}
+	f.Path = path
+	f.TemplateBody = body
+	return f
+}
+
+func TestScaffold_Execute(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "overwrite.yaml", []byte("a\nb\nc\n"), 0600)
+	afero.WriteFile(fs, "unchanged.yaml", []byte("a\n"), 0600)
+	afero.WriteFile(fs, "skip.yaml", []byte("a\n"), 0600)
+
+	s := scaffold.New(fs, input.Options{})
+	err := s.Execute(
+		newFile("create.yaml", "a\n", true),
+		newFile("overwrite.yaml", "a\nB\nc\n", true),
+		newFile("unchanged.yaml", "a\n", true),
+		newFile("skip.yaml", "b\n", false),
+	)
+	if err != nil {
+		t.Fatalf("Scaffold.Execute() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		want     scaffold.Action
+		wantDiff string
+	}{
+		{"create.yaml", scaffold.CreateAction, "--- /dev/null\n+++ b/create.yaml\n@@ -0,0 +1,1 @@\n+a\n"},
+		{"overwrite.yaml", scaffold.OverwriteAction, "--- a/overwrite.yaml\n+++ b/overwrite.yaml\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"},
+		{"unchanged.yaml", scaffold.UnchangedAction, ""},
+		{"skip.yaml", scaffold.SkipAction, ""},
+	}
+	changes := s.GetChanges()
+	if len(changes) != len(tests) {
+		t.Fatalf("Scaffold.GetChanges() = %v, want %v changes", changes, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if changes[i].Path != tt.path || changes[i].Action != tt.want {
+				t.Errorf("Scaffold.GetChanges() = %v %v, want %v %v", changes[i].Action, changes[i].Path, tt.want, tt.path)
+			}
+			if got := changes[i].Diff(); got != tt.wantDiff {
+				t.Errorf("Change.Diff() = %q, want %q", got, tt.wantDiff)
+			}
+		})
+	}
+
+	if got, _ := afero.ReadFile(fs, "skip.yaml"); string(got) != "a\n" {
+		t.Errorf("Scaffold.Execute() overwrote skip.yaml = %q", got)
+	}
+}
+
+func TestScaffold_ExecuteRegions(t *testing.T) {
+	const generated = "a\n# +awsctrl:custom-begin:hooks\n# +awsctrl:custom-end:hooks\nb\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		wantErr  string
+	}{
+		{"TestKeepsRegion", "A\n# +awsctrl:custom-begin:hooks\ncustom\n# +awsctrl:custom-end:hooks\nB\n", "a\n# +awsctrl:custom-begin:hooks\ncustom\n# +awsctrl:custom-end:hooks\nb\n", ""},
+		{"TestWithoutRegions", "A\n", generated, ""},
+		{"TestRemovedRegion", "# +awsctrl:custom-begin:other\ncustom\n# +awsctrl:custom-end:other\n", "", "custom region other was removed"},
+		{"TestRegionDoesntEnd", "# +awsctrl:custom-begin:hooks\ncustom\n", "", "custom region hooks doesn't end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			afero.WriteFile(fs, "file.yaml", []byte(tt.existing), 0600)
+
+			err := scaffold.New(fs, input.Options{}).Execute(newFile("file.yaml", generated, true))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Scaffold.Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if got, _ := afero.ReadFile(fs, "file.yaml"); string(got) != tt.existing {
+					t.Errorf("Scaffold.Execute() overwrote the file = %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scaffold.Execute() error = %v", err)
+			}
+
+			if got, _ := afero.ReadFile(fs, "file.yaml"); string(got) != tt.want {
+				t.Errorf("Scaffold.Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type templated struct {
+	*file
+}
+
+func (in *templated) TemplateName() string { return "templated" }
+
+func (in *templated) DefaultTemplate() string { return in.TemplateBody }
+
+func TestScaffold_ExecuteTemplatesDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"TestDefault", "", "default\n"},
+		{"TestOverride", "override {{ .Path }}\n", "override file.yaml\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			if tt.template != "" {
+				afero.WriteFile(fs, "templates/templated.tmpl", []byte(tt.template), 0600)
+			}
+
+			s := scaffold.New(fs, input.Options{TemplatesDir: "templates"})
+			if err := s.Execute(&templated{newFile("file.yaml", "default\n", true)}); err != nil {
+				t.Fatalf("Scaffold.Execute() error = %v", err)
+			}
+
+			if got, _ := afero.ReadFile(fs, "file.yaml"); string(got) != tt.want {
+				t.Errorf("Scaffold.Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,7 +19,6 @@ package stackobject
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -27,9 +26,12 @@ import (
 
 	"go.awsctrl.io/generator/pkg/input"
 	"go.awsctrl.io/generator/pkg/resource"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 var _ input.File = &StackObject{}
+var _ input.Template = &StackObject{}
 
 // StackObject scaffolds the apis/<groups>/<version>/zz_generated.<resource>.stackobject.go
 type StackObject struct {
@@ -51,18 +53,6 @@ func (in *StackObject) GetInput() input.Input {
 	return in.Input
 }
 
-func inBlacklist(attr string, r *resource.Resource) bool {
-	if r.Kind == "DomainName" && r.Group == "apigateway" {
-		switch attr {
-		case "DistributionHostedZoneId":
-			return true
-		case "DistributionDomainName":
-			return true
-		}
-	}
-	return false
-}
-
 // GenerateAttributes will return the templating functions
 func (in *StackObject) GenerateAttributes() string {
 	lines := []string{}
@@ -77,26 +67,71 @@ func (in *StackObject) GenerateAttributes() string {
 
 	for _, name := range keys {
 		attr := attributes[name]
-		if attr.GetType() == "List" || inBlacklist(name, in.Resource) {
+		if !in.Resource.IncludesAttribute(name) {
 			continue
 		}
-		lines = appendstrf(lines, `"%v": map[string]interface{}{`, name)
-		if attr.GetType() == "String" || attr.GetType() == "Integer" {
+		outputname := resource.OutputName(name)
+		lines = appendstrf(lines, `"%v": map[string]interface{}{`, outputname)
+		if attr.GetType() == "List" {
+			// outputs can only be strings so lists are joined and split when read
+			lines = appendstrf(lines, `"Value": map[string]interface{}{"Fn::Join": []interface{}{",", cloudformation.GetAtt("%v", "%v")}},`, in.Resource.Kind, name)
+		} else {
 			lines = appendstrf(lines, `"Value": cloudformation.GetAtt("%v", "%v"),`, in.Resource.Kind, name)
-			lines = appendstrf(lines, `"Export": map[string]interface{}{"Name": in.Name + "%v",},`, name)
 		}
-		// TODO(christopherhein): figure out how to make goformation output join functions
-		// if attr.GetType() == "List" {
-		// 	lines = appendstrf(lines, `"Value": intrinsics.FnJoin(",", cloudformation.GetAtt("%v", "%v")),`, in.Resource.Kind, name)
-		// }
+		lines = appendstrf(lines, `"Export": map[string]interface{}{"Name": in.Name + "%v",},`, outputname)
 		lines = appendstrf(lines, `},`)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
-// GenerateTemplateFunctions generates all the resource definition functions
-func (in *StackObject) GenerateTemplateFunctions() string {
+// GenerateOutputs will return the conversion from the stack outputs
+func (in *StackObject) GenerateOutputs() string {
+	lines := []string{}
+
+	attributes := in.Resource.ResourceType.GetAttributes()
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		attr := attributes[name]
+		if !in.Resource.IncludesAttribute(name) {
+			continue
+		}
+		outputname := resource.OutputName(name)
+		lines = appendstrf(lines, `if value, ok := outputs["%v"]; ok && value != "" {`, outputname)
+		switch attr.GetOutputType() {
+		case "[]string":
+			lines = appendstrf(lines, `output.%v = strings.Split(value, ",")`, outputname)
+		case "int":
+			lines = appendstrf(lines, `parsed, err := strconv.Atoi(value)`)
+			lines = appendstrf(lines, `if err != nil {`)
+			lines = appendstrf(lines, `return output, fmt.Errorf("output %v: %%v", err)`, outputname)
+			lines = appendstrf(lines, `}`)
+			lines = appendstrf(lines, `output.%v = parsed`, outputname)
+		case "int64":
+			lines = appendstrf(lines, `parsed, err := strconv.ParseInt(value, 10, 64)`)
+			lines = appendstrf(lines, `if err != nil {`)
+			lines = appendstrf(lines, `return output, fmt.Errorf("output %v: %%v", err)`, outputname)
+			lines = appendstrf(lines, `}`)
+			lines = appendstrf(lines, `output.%v = parsed`, outputname)
+		default:
+			lines = appendstrf(lines, `output.%v = value`, outputname)
+		}
+		lines = appendstrf(lines, `}`)
+		lines = appendblank(lines)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// GenerateTemplateFunctions generates all the resource definition functions,
+// the errors of all the properties are returned together
+func (in *StackObject) GenerateTemplateFunctions() (string, error) {
 	lines := []string{}
 
 	groupLower := strings.ToLower(in.Resource.Group)
@@ -128,13 +163,16 @@ func (in *StackObject) GenerateTemplateFunctions() string {
 	// }
 	// {{ end }}
 
-	lines = in.loopTemplateProperties(lines, groupLower+kind, "in.Spec", in.Resource.ResourceType.GetProperties())
+	lines, errs := in.loopTemplateProperties(lines, groupLower+kind, "in.Spec", "", in.Resource.ResourceType.GetProperties())
+	if len(errs) > 0 {
+		return "", utilerrors.NewAggregate(errs)
+	}
 
 	lines = appendstrf(lines, "template.Resources = map[string]cloudformation.Resource{")
 	lines = appendstrf(lines, `"%v": %v,`, kind, attrName)
 	lines = appendstrf(lines, "}")
 
-	return strings.Join(lines, "\n")
+	return strings.Join(lines, "\n"), nil
 }
 
 type ifblock struct {
@@ -142,7 +180,8 @@ type ifblock struct {
 	defaultVal string
 }
 
-func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBase string, propertyMap map[string]resource.Property) []string {
+func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBase, parent string, propertyMap map[string]resource.Property) ([]string, []error) {
+	errs := []error{}
 	groupLower := strings.ToLower(in.Resource.Group)
 	kind := in.Resource.Kind
 
@@ -153,24 +192,36 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 	sort.Strings(keys)
 
 	for _, name := range keys {
-		property := propertyMap[name]
-		originalname := name
-		if resource.IdOrArn(originalname) && property.GetType() == "String" {
-			name = resource.TrimIdOrArn(name) + "Ref"
+		if !in.Resource.IncludesProperty(parent, name) {
+			continue
 		}
 
-		if resource.IdsOrArns(originalname) && property.GetItemType() == "String" {
-			name = resource.TrimIdsOrArns(name) + "Refs"
+		property := propertyMap[name]
+		originalname := name
+		name = in.Resource.GetFieldName(parent, originalname, property)
+
+		if resource.IsMapTags(originalname, property) {
+			// cloudformation takes these tags as a key value object
+			mapAttrName := attrName + name
+			lines = appendstrf(lines, `if len(%v.%v) > 0 {`, paramBase, name)
+			lines = appendstrf(lines, `%v := map[string]string{}`, mapAttrName)
+			lines = appendstrf(lines, `for _, item := range %v.%v {`, paramBase, name)
+			lines = appendstrf(lines, `%v[item.Key] = item.Value`, mapAttrName)
+			lines = appendstrf(lines, `}`)
+			lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, mapAttrName)
+			lines = appendstrf(lines, `}`)
+			lines = appendblank(lines)
+			continue
 		}
 
 		if property.IsParameter() {
 			if property.GetType() == "Json" {
-				lines = appendstrf(lines, `if %v.%v != "" {`, paramBase, name)
-				lines = appendstrf(lines, `%v := make(map[string]interface{})`, attrName+"JSON")
-				lines = appendstrf(lines, "err := json.Unmarshal([]byte(%v.%v), &%v)", paramBase, name, attrName+"JSON")
+				lines = appendstrf(lines, `if %v.%v != nil {`, paramBase, name)
+				lines = appendstrf(lines, `var %v interface{}`, attrName+"JSON")
+				lines = appendstrf(lines, "err := json.Unmarshal(%v.%v.Raw, &%v)", paramBase, name, attrName+"JSON")
 				lines = appendstrf(lines, `if err != nil { return "", err }`)
-				lines = appendstrf(lines, `%v.%v = %v`, attrName, name, attrName+"JSON")
-			} else if resource.IdOrArn(originalname) && property.GetType() == "String" {
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, attrName+"JSON")
+			} else if in.Resource.IsReference(parent, originalname, property) {
 				subAttrName := attrName + name + "Item"
 				ifblocks := []ifblock{
 					ifblock{
@@ -204,27 +255,31 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 					if originalname == in.Resource.Kind+"Name" {
 						lines = appendstrf(lines, `// TODO(christopherhein) move these to a defaulter`)
 						lines = appendstrf(lines, `if %v.%v == "" {`, paramBase, name)
-						lines = appendstrf(lines, `%v.%v = in.Name`, attrName, name)
+						lines = appendstrf(lines, `%v.%v = in.Name`, attrName, originalname)
 						lines = appendstrf(lines, `}`)
 						lines = appendblank(lines)
 					}
 					lines = appendstrf(lines, `if %v.%v != "" {`, paramBase, name)
 
-				case "int":
-					if property.GetType() == "Double" {
-						lines = appendstrf(lines, `if float64(%v.%v) != %v.%v {`, paramBase, name, attrName, name)
-					} else {
-						lines = appendstrf(lines, `if %v.%v != %v.%v {`, paramBase, name, attrName, name)
-					}
+				case "int", "int64":
+					lines = appendstrf(lines, `if %v.%v != %v.%v {`, paramBase, name, attrName, originalname)
 				case "bool":
 					lines = appendstrf(lines, `if %v.%v || !%v.%v {`, paramBase, name, paramBase, name)
+				case "metav1.Time":
+					lines = appendstrf(lines, `if !%v.%v.IsZero() {`, paramBase, name)
 				}
 
 				switch property.GetType() {
 				case "Double":
-					lines = appendstrf(lines, `%v.%v = float64(%v.%v)`, attrName, name, paramBase, name)
+					lines = appendstrf(lines, `%v, err := strconv.ParseFloat(%v.%v, 64)`, lowerfirst(originalname), paramBase, name)
+					lines = appendstrf(lines, `if err != nil {`)
+					lines = appendstrf(lines, `return "", err`)
+					lines = appendstrf(lines, `}`)
+					lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, lowerfirst(originalname))
+				case "Timestamp":
+					lines = appendstrf(lines, `%v.%v = %v.%v.UTC().Format(time.RFC3339)`, attrName, originalname, paramBase, name)
 				default:
-					lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, name, paramBase, name)
+					lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, originalname, paramBase, name)
 				}
 
 			}
@@ -233,23 +288,52 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 		}
 
 		if property.IsMap() {
-			lines = appendstrf(lines, `if !reflect.DeepEqual(%v.%v, %v{}) {`, paramBase, name, property.GetGoType(kind))
-			if property.GetItemType() == "Boolean" || property.GetItemType() == "String" {
-				lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, name, paramBase, name)
-			} else {
+			lines = appendstrf(lines, `if len(%v.%v) > 0 {`, paramBase, name)
+			mapAttrName := attrName + name
+			switch property.GetItemType() {
+			case "Boolean", "String", "Integer", "Long":
+				lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, originalname, paramBase, name)
+			case "Double":
+				lines = appendstrf(lines, `%v := map[string]float64{}`, mapAttrName)
+				lines = appendstrf(lines, `for key, item := range %v.%v {`, paramBase, name)
+				lines = appendstrf(lines, `value, err := strconv.ParseFloat(item, 64)`)
+				lines = appendstrf(lines, `if err != nil {`)
+				lines = appendstrf(lines, `return "", err`)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v[key] = value`, mapAttrName)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, mapAttrName)
+			case "Timestamp":
+				lines = appendstrf(lines, `%v := map[string]string{}`, mapAttrName)
+				lines = appendstrf(lines, `for key, item := range %v.%v {`, paramBase, name)
+				lines = appendstrf(lines, `%v[key] = item.UTC().Format(time.RFC3339)`, mapAttrName)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, mapAttrName)
+			case "Json":
+				lines = appendstrf(lines, `%v := map[string]interface{}{}`, mapAttrName)
+				lines = appendstrf(lines, `for key, item := range %v.%v {`, paramBase, name)
+				lines = appendstrf(lines, `var value interface{}`)
+				lines = appendstrf(lines, `if err := json.Unmarshal(item.Raw, &value); err != nil {`)
+				lines = appendstrf(lines, `return "", err`)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v[key] = value`, mapAttrName)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, mapAttrName)
+			default:
 				propertyTypeName := attrName + property.GetItemType()
 				lines = appendstrf(lines, `for key, prop := range %v.%v {`, paramBase, name)
 				lines = appendstrf(lines, `%v := %v.%v{}`, propertyTypeName, groupLower, property.GetSingularGoType(kind))
 
 				propType, ok := in.Resource.PropertyTypes[property.GetItemType()]
-				if !ok {
-					fmt.Printf("failed loading map subresource %v for resource kind %v and name %v\n", property.GetItemType(), kind, name)
-					os.Exit(1)
+				if ok {
+					var nested []error
+					lines, nested = in.loopTemplateProperties(lines, propertyTypeName, "prop", property.GetItemType(), propType.GetProperties())
+					errs = append(errs, nested...)
+				} else {
+					errs = append(errs, in.missingPropertyType(parent, originalname, property.GetItemType()))
 				}
 
-				lines = in.loopTemplateProperties(lines, propertyTypeName, "prop", propType.GetProperties())
-
-				lines = appendstrf(lines, `%v.%v[key] = %v`, attrName, name, propertyTypeName)
+				lines = appendstrf(lines, `%v.%v[key] = %v`, attrName, originalname, propertyTypeName)
 				lines = appendstrf(lines, `}`)
 			}
 			lines = appendstrf(lines, `}`)
@@ -264,14 +348,15 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 			lines = appendblank(lines)
 
 			propType, ok := in.Resource.PropertyTypes[property.GetType()]
-			if !ok {
-				fmt.Printf("failed loading nested subresource %v for resource kind %v and name %v\n", property.GetType(), kind, name)
-				os.Exit(1)
+			if ok {
+				var nested []error
+				lines, nested = in.loopTemplateProperties(lines, propertyTypeName, fmt.Sprintf("%v.%v", paramBase, name), property.GetType(), propType.GetProperties())
+				errs = append(errs, nested...)
+			} else {
+				errs = append(errs, in.missingPropertyType(parent, originalname, property.GetType()))
 			}
 
-			lines = in.loopTemplateProperties(lines, propertyTypeName, fmt.Sprintf("%v.%v", paramBase, name), propType.GetProperties())
-
-			lines = appendstrf(lines, `%v.%v = &%v`, attrName, name, propertyTypeName)
+			lines = appendstrf(lines, `%v.%v = &%v`, attrName, originalname, propertyTypeName)
 			lines = appendstrf(lines, `}`)
 			lines = appendblank(lines)
 
@@ -282,34 +367,20 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 			propertyTypeName := attrName + property.GetItemType()
 
 			if property.GetItemType() == "Tag" {
-				lines = appendstrf(lines, `// TODO(christopherhein): implement tags this could be easy now that I have the mechanims of nested objects`)
-				// TODO(christopherhein): implement tags, right now this causes issues with -
-				// https://github.com/kubernetes-sigs/controller-tools/blob/master/pkg/crd/flatten.go#L124-L127
-
-				// tagGroup := "tags"
-				// tagKind := "Tag"
-				// lines = appendstrf(lines, "%v := []%v.%v{}", listAttrName, tagGroup, tagKind)
-				// lines = appendblank(lines)
-				// lines = appendstrf(lines, "for _, item := range in.Spec.%v {", name)
-				// lines = appendstrf(lines, "%v := %v.%v{}", propertyTypeName, tagGroup, tagKind)
-				// lines = appendblank(lines)
-
-				// lines = appendstrf(lines, `if %v.%v != "" {`, "item", "Key")
-				// lines = appendstrf(lines, `%v.%v = %v.%v`, propertyTypeName, "Key", "item", "Key")
-				// lines = appendstrf(lines, "}")
-				// lines = appendblank(lines)
-
-				// lines = appendstrf(lines, `if %v.%v != "" {`, "item", "Value")
-				// lines = appendstrf(lines, `%v.%v = %v.%v`, propertyTypeName, "Value", "item", "Value")
-				// lines = appendstrf(lines, "}")
-				// lines = appendblank(lines)
-
-				// lines = appendstrf(lines, "}")
-				// lines = appendblank(lines)
-				// lines = appendstrf(lines, "if len(%v) > 0 {", listAttrName)
-				// lines = appendstrf(lines, `%v.%v = %v`, attrName, name, listAttrName)
-				// lines = appendstrf(lines, "}")
-			} else if resource.IdsOrArns(originalname) {
+				// goformation uses the shared tags.Tag for every resource
+				lines = appendstrf(lines, "%v := []tags.Tag{}", listAttrName)
+				lines = appendblank(lines)
+				lines = appendstrf(lines, "for _, item := range %v.%v {", paramBase, name)
+				lines = appendstrf(lines, "%v = append(%v, tags.Tag{", listAttrName, listAttrName)
+				lines = appendstrf(lines, "Key: item.Key,")
+				lines = appendstrf(lines, "Value: item.Value,")
+				lines = appendstrf(lines, "})")
+				lines = appendstrf(lines, "}")
+				lines = appendblank(lines)
+				lines = appendstrf(lines, "if len(%v) > 0 {", listAttrName)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, listAttrName)
+				lines = appendstrf(lines, "}")
+			} else if in.Resource.IsReferenceList(parent, originalname, property) {
 				lines = appendstrf(lines, `if len(%v.%v) > 0 {`, paramBase, name)
 				if property.GetSingularGoType(kind) == "string" {
 					subAttrName := attrName + name
@@ -346,15 +417,44 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 
 			} else if property.IsListParameter() {
 				lines = appendstrf(lines, `if len(%v.%v) > 0 {`, paramBase, name)
-				if property.GetSingularGoType(kind) == "string" {
-					lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, name, paramBase, name)
+				if property.GetItemType() == "Double" {
+					lines = appendstrf(lines, `%v := []float64{}`, listAttrName)
+					lines = appendstrf(lines, `for _, item := range %v.%v {`, paramBase, name)
+					lines = appendstrf(lines, `value, err := strconv.ParseFloat(item, 64)`)
+					lines = appendstrf(lines, `if err != nil {`)
+					lines = appendstrf(lines, `return "", err`)
+					lines = appendstrf(lines, `}`)
+					lines = appendstrf(lines, `%v = append(%v, value)`, listAttrName, listAttrName)
+					lines = appendstrf(lines, `}`)
+					lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, listAttrName)
+				} else if property.GetItemType() == "Timestamp" {
+					lines = appendstrf(lines, `%v := []string{}`, listAttrName)
+					lines = appendstrf(lines, `for _, item := range %v.%v {`, paramBase, name)
+					lines = appendstrf(lines, `%v = append(%v, item.UTC().Format(time.RFC3339))`, listAttrName, listAttrName)
+					lines = appendstrf(lines, `}`)
+					lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, listAttrName)
+				} else if property.GetSingularGoType(kind) == "string" {
+					lines = appendstrf(lines, `%v.%v = %v.%v`, attrName, originalname, paramBase, name)
 				} else {
 					lines = appendstrf(lines, `%vItem := []%v{}`, attrName, property.GetSingularGoType(kind))
 					lines = appendstrf(lines, `%vItem = append(%vItem, %v.%v...)`, attrName, attrName, paramBase, name)
-					lines = appendstrf(lines, `%v.%v = %vItem`, attrName, name, attrName)
+					lines = appendstrf(lines, `%v.%v = %vItem`, attrName, originalname, attrName)
 				}
 				lines = appendstrf(lines, "}")
 				lines = appendblank(lines)
+			} else if property.GetItemType() == "Json" {
+				lines = appendstrf(lines, `if len(%v.%v) > 0 {`, paramBase, name)
+				lines = appendstrf(lines, `%v := []interface{}{}`, listAttrName)
+				lines = appendstrf(lines, `for _, item := range %v.%v {`, paramBase, name)
+				lines = appendstrf(lines, `var value interface{}`)
+				lines = appendstrf(lines, `if err := json.Unmarshal(item.Raw, &value); err != nil {`)
+				lines = appendstrf(lines, `return "", err`)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v = append(%v, value)`, listAttrName, listAttrName)
+				lines = appendstrf(lines, `}`)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, listAttrName)
+				lines = appendstrf(lines, "}")
+				lines = appendblank(lines)
 			} else {
 				lines = appendstrf(lines, "%v := []%v.%v_%v{}", listAttrName, groupLower, kind, property.GetItemType())
 				lines = appendblank(lines)
@@ -363,17 +463,18 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 				lines = appendblank(lines)
 
 				propType, ok := in.Resource.PropertyTypes[property.GetItemType()]
-				if !ok {
-					fmt.Printf("failed loading list subresource %v for resource kind %v and name %v\n", property.GetItemType(), kind, name)
-					os.Exit(1)
+				if ok {
+					var nested []error
+					lines, nested = in.loopTemplateProperties(lines, propertyTypeName, "item", property.GetItemType(), propType.GetProperties())
+					errs = append(errs, nested...)
+				} else {
+					errs = append(errs, in.missingPropertyType(parent, originalname, property.GetItemType()))
 				}
 
-				lines = in.loopTemplateProperties(lines, propertyTypeName, "item", propType.GetProperties())
-
 				lines = appendstrf(lines, "}")
 				lines = appendblank(lines)
 				lines = appendstrf(lines, "if len(%v) > 0 {", listAttrName)
-				lines = appendstrf(lines, `%v.%v = %v`, attrName, name, listAttrName)
+				lines = appendstrf(lines, `%v.%v = %v`, attrName, originalname, listAttrName)
 				lines = appendstrf(lines, "}")
 			}
 
@@ -381,7 +482,12 @@ func (in *StackObject) loopTemplateProperties(lines []string, attrName, paramBas
 	}
 	lines = appendblank(lines)
 
-	return lines
+	return lines, errs
+}
+
+// missingPropertyType returns the error for a property type which isn't in the specification
+func (in *StackObject) missingPropertyType(parent, name, propertytype string) error {
+	return fmt.Errorf("%v: property %v: property type %v not found", in.Resource.ResourceName, resource.PropertyPath(parent, name), propertytype)
 }
 
 func appendstrf(slice []string, temp string, a ...interface{}) []string {
@@ -401,6 +507,12 @@ func lowerfirst(str string) string {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *StackObject) ShouldOverride() bool { return true }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *StackObject) TemplateName() string { return "stackobject" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *StackObject) DefaultTemplate() string { return stackobjectTemplate }
+
 // Validate validates the values
 func (in *StackObject) Validate() error {
 	return in.Resource.Validate()
@@ -423,7 +535,6 @@ import (
 	"github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/awslabs/goformation/v4/cloudformation/tags"
 	"github.com/awslabs/goformation/v4/cloudformation/{{ .Resource.Group  }}"
-	"github.com/awslabs/goformation/v4/intrinsics"
 )
 
 // GetNotificationARNs is an autogenerated deepcopy function, will return notifications for stack
@@ -456,13 +567,27 @@ func (in *{{ .Resource.Kind }}) GetTemplate(client dynamic.Interface) (string, e
 
 	{{ noescape .GenerateTemplateFunctions }}
 
-	// json, err := template.JSONWithOptions(&intrinsics.ProcessorOptions{NoEvaluateConditions: true})
-	json, err := template.JSON()
+	// +awsctrl:custom-begin:template
+	// +awsctrl:custom-end:template
+
+	// the intrinsic functions are left for CloudFormation to resolve
+	body, err := json.Marshal(template)
 	if err != nil {
 		return "", err
 	}
 
-	return string(json), nil
+	return string(body), nil
+}
+
+// Get{{ .Resource.Kind }}Output will convert the stack outputs into the typed {{ .Resource.Kind }}Output
+func Get{{ .Resource.Kind }}Output(outputs map[string]string) ({{ .Resource.Kind }}Output, error) {
+	output := {{ .Resource.Kind }}Output{
+		Ref: outputs["ResourceRef"],
+	}
+
+	{{ noescape .GenerateOutputs }}
+
+	return output, nil
 }
 
 // GetStackID will return stackID
@@ -535,4 +660,7 @@ func (in *{{ .Resource.Kind }}) TemplateVersionChanged() bool {
 func (in *{{ .Resource.Kind }}) SetStatus(status *metav1alpha1.StatusMeta) {
 	in.Status.StatusMeta = *status
 }
+
+// +awsctrl:custom-begin:stackobject
+// +awsctrl:custom-end:stackobject
 `
@@ -0,0 +1,174 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stackobject_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/resource"
+	"go.awsctrl.io/generator/pkg/scaffold"
+	"go.awsctrl.io/generator/pkg/stackobject"
+
+	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
+)
+
+func newStackObject(attributes map[string]resource.Attribute) *stackobject.StackObject {
+	return &stackobject.StackObject{
+		Resource: &resource.Resource{
+			Resource: kbresource.Resource{
+				Group:   "ecr",
+				Version: "v1alpha1",
+				Kind:    "Repository",
+			},
+			ResourceName: "AWS::ECR::Repository",
+			ResourceType: &resource.BaseResource{
+				Attributes: attributes,
+				Properties: map[string]resource.Property{},
+			},
+			PropertyTypes: map[string]resource.ResourceType{},
+		},
+	}
+}
+
+func TestStackObject_GenerateOutputs(t *testing.T) {
+	in := newStackObject(map[string]resource.Attribute{
+		"Arn":            &resource.BaseAttribute{PrimitiveType: "String"},
+		"Endpoint.Port":  &resource.BaseAttribute{PrimitiveType: "Integer"},
+		"Size":           &resource.BaseAttribute{PrimitiveType: "Long"},
+		"Ipv6CidrBlocks": &resource.BaseAttribute{Type: "List", PrimitiveItemType: "String"},
+	})
+
+	got := in.GenerateOutputs()
+	for _, want := range []string{
+		"output.Arn = value",
+		"parsed, err := strconv.Atoi(value)",
+		"output.EndpointPort = parsed",
+		"parsed, err := strconv.ParseInt(value, 10, 64)",
+		"output.Size = parsed",
+		`output.Ipv6CidrBlocks = strings.Split(value, ",")`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("StackObject.GenerateOutputs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStackObject_GetTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	in := newStackObject(map[string]resource.Attribute{
+		"Arn":            &resource.BaseAttribute{PrimitiveType: "String"},
+		"Ipv6CidrBlocks": &resource.BaseAttribute{Type: "List", PrimitiveItemType: "String"},
+	})
+
+	if err := scaffold.New(fs, input.Options{}).Execute(in); err != nil {
+		t.Fatalf("Scaffold.Execute() error = %v", err)
+	}
+
+	body, err := afero.ReadFile(fs, "apis/ecr/v1alpha1/zz_generated.repository.stackobject.go")
+	if err != nil {
+		t.Fatalf("afero.ReadFile() error = %v", err)
+	}
+
+	got := string(body)
+	for _, want := range []string{
+		`cloudformation.Ref("Repository")`,
+		`cloudformation.GetAtt("Repository", "Arn")`,
+		`map[string]interface{}{"Fn::Join": []interface{}{",", cloudformation.GetAtt("Repository", "Ipv6CidrBlocks")}}`,
+		"body, err := json.Marshal(template)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("StackObject template = %v, want %v", got, want)
+		}
+	}
+
+	// resolving the intrinsic functions locally replaces them with null
+	if strings.Contains(got, "intrinsics.") {
+		t.Errorf("StackObject template resolves the intrinsic functions before CloudFormation")
+	}
+}
+
+func TestStackObject_GenerateTemplateFunctions(t *testing.T) {
+	in := newStackObject(map[string]resource.Attribute{})
+	in.Resource.ResourceType.SetProperties(map[string]resource.Property{
+		"Policies":     &resource.BaseProperty{Type: "List", ItemType: "Json"},
+		"Settings":     &resource.BaseProperty{Type: "Map", ItemType: "Json"},
+		"Limits":       &resource.BaseProperty{Type: "Map", ItemType: "Integer"},
+		"Ratios":       &resource.BaseProperty{Type: "Map", ItemType: "Double"},
+		"ExpiresAt":    &resource.BaseProperty{Type: "Map", ItemType: "Timestamp"},
+		"Environments": &resource.BaseProperty{Type: "Map", ItemType: "String"},
+	})
+
+	got, err := in.GenerateTemplateFunctions()
+	if err != nil {
+		t.Fatalf("StackObject.GenerateTemplateFunctions() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"if err := json.Unmarshal(item.Raw, &value); err != nil {",
+		"ecrRepositoryPolicies = append(ecrRepositoryPolicies, value)",
+		"ecrRepositorySettings[key] = value",
+		"ecrRepository.Limits = in.Spec.Limits",
+		"value, err := strconv.ParseFloat(item, 64)",
+		"ecrRepositoryExpiresAt[key] = item.UTC().Format(time.RFC3339)",
+		"ecrRepository.Environments = in.Spec.Environments",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("StackObject.GenerateTemplateFunctions() = %v, want %v", got, want)
+		}
+	}
+
+	// the list and map items aren't property types
+	if strings.Contains(got, "Repository_") {
+		t.Errorf("StackObject.GenerateTemplateFunctions() = %v, want no property types", got)
+	}
+}
+
+func TestStackObject_GenerateTemplateFunctionsMapTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags resource.Property
+	}{
+		{"TestJsonTags", &resource.BaseProperty{Type: "Json"}},
+		{"TestMapTags", &resource.BaseProperty{Type: "Map", ItemType: "String"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newStackObject(map[string]resource.Attribute{})
+			in.Resource.ResourceType.SetProperties(map[string]resource.Property{"Tags": tt.tags})
+
+			got, err := in.GenerateTemplateFunctions()
+			if err != nil {
+				t.Fatalf("StackObject.GenerateTemplateFunctions() error = %v", err)
+			}
+
+			for _, want := range []string{
+				"ecrRepositoryTags := map[string]string{}",
+				"for _, item := range in.Spec.Tags {",
+				"ecrRepositoryTags[item.Key] = item.Value",
+				"ecrRepository.Tags = ecrRepositoryTags",
+			} {
+				if !strings.Contains(got, want) {
+					t.Errorf("StackObject.GenerateTemplateFunctions() = %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -18,17 +18,23 @@ limitations under the License.
 package types
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
+	"go.awsctrl.io/generator/pkg/breaking"
 	"go.awsctrl.io/generator/pkg/input"
+	"go.awsctrl.io/generator/pkg/reference"
 	"go.awsctrl.io/generator/pkg/resource"
 )
 
 var _ input.File = &Types{}
+var _ input.Template = &Types{}
+var _ input.Compatible = &Types{}
 
 // Types scaffolds the apis/<group>/<version>/<resource>_types.go
 type Types struct {
@@ -39,6 +45,10 @@ type Types struct {
 
 	// Resources stores the entire list of resources
 	Resources []resource.Resource
+
+	// References indexes the Resources for the ObjectReference kinds, it's
+	// built from the Resources when it's nil
+	References *reference.Index
 }
 
 // GetInput load the input and configure for Scaffolding
@@ -53,9 +63,36 @@ func (in *Types) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *Types) ShouldOverride() bool { return true }
 
-// GetProperties returns the attributes for all resource types
-func (in *Types) GetProperties(props map[string]resource.Property) string {
+// TemplateName returns the name of the template in the templates directory
+func (in *Types) TemplateName() string { return "types" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *Types) DefaultTemplate() string { return typesTemplate }
+
+// CheckCompatible will fail when the generated structs break the existing CRD
+func (in *Types) CheckCompatible(existing, contents []byte) error {
+	changes, err := breaking.Compare(existing, contents)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	lines := []string{"breaking changes found"}
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	return errors.New(strings.Join(lines, "\n  "))
+}
+
+// GetProperties returns the attributes for all resource types, parent is the
+// property type name and empty for the spec properties
+func (in *Types) GetProperties(props map[string]resource.Property, parent string) string {
 	lines := []string{}
+	// immutability can only be enforced on the spec properties
+	root := parent == ""
 
 	keys := make([]string, 0, len(props))
 	for k := range props {
@@ -64,51 +101,241 @@ func (in *Types) GetProperties(props map[string]resource.Property) string {
 	sort.Strings(keys)
 
 	for _, name := range keys {
-		property := props[name]
-		originalname := name
-		if resource.IdOrArn(originalname) && property.GetType() == "String" {
-			name = resource.TrimIdOrArn(name) + "Ref"
+		if !in.Resource.IncludesProperty(parent, name) {
+			continue
 		}
 
-		if resource.IdsOrArns(originalname) && property.GetItemType() == "String" {
-			name = resource.TrimIdsOrArns(name) + "Refs"
-		}
+		property := props[name]
+		originalname := name
+		name = in.Resource.GetFieldName(parent, originalname, property)
+		jsonname := in.Resource.GetJSONName(parent, originalname, property)
+		required := in.Resource.IsRequired(parent, originalname, property)
 
-		// TODO(christopherhein) implement tags
-		if name == "Tags" {
-			// fmt.Printf("tags resource found %+v\n", property)
-			continue
-		}
 		lines = appendstrf(lines, `// %v %v`, name, property.GetDocumentation())
-		required := ""
-		if !property.GetRequired() ||
+		lines = append(lines, getUpdateDocumentation(property, root)...)
+		omitempty := ""
+		if !required ||
 			originalname != in.Resource.Kind+"Name" ||
 			!property.IsParameter() {
-			required = ",omitempty"
+			omitempty = ",omitempty"
 		}
 		param := ""
-		if property.IsParameter() {
+		// timestamps and json are structs which the cloudformation encoder can't flatten
+		if property.IsParameter() && property.GetType() != "Timestamp" && property.GetType() != "Json" {
 			param = ",Parameter"
 		}
 
 		goType := property.GetGoType(in.Resource.Kind)
-		if resource.IdOrArn(originalname) && property.GetType() == "String" {
+		// map tags use the same tag list as the other resources
+		if resource.IsMapTags(originalname, property) {
+			goType = "[]" + in.Resource.Kind + "_Tag"
+		}
+		if in.Resource.IsReference(parent, originalname, property) {
 			goType = "metav1alpha1.ObjectReference"
 		}
 
-		if resource.IdsOrArns(originalname) && property.GetItemType() == "String" {
+		if in.Resource.IsReferenceList(parent, originalname, property) {
 			goType = "[]metav1alpha1.ObjectReference"
 		}
 
-		lines = appendstrf(lines, `%v %v `+"`"+`json:"%v%v" cloudformation:"%v%v"`+"`", name, goType, lowerfirst(name), required, originalname, param)
+		isReference := in.Resource.IsReference(parent, originalname, property) || in.Resource.IsReferenceList(parent, originalname, property)
+		kinds := []string{}
+		if isReference {
+			kinds = in.getReferenceKinds(parent, originalname)
+		}
+		if len(kinds) > 0 {
+			lines = appendstrf(lines, `//`)
+			lines = appendstrf(lines, `// %v references a %v`, name, strings.Join(kinds, " or "))
+		}
+
+		lines = append(lines, in.getMarkers(originalname, required, property, goType)...)
+		if len(kinds) > 0 {
+			lines = appendstrf(lines, `// +awsctrl:reference:kinds=%v`, strings.Join(kinds, ";"))
+		}
+		lines = append(lines, getUpdateMarkers(property)...)
+		if root && property.GetUpdateType() == resource.ImmutableType {
+			lines = appendstrf(lines, `// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="%v is immutable"`, jsonname)
+		}
+		lines = appendstrf(lines, `%v %v `+"`"+`json:"%v%v" cloudformation:"%v%v"`+"`", name, goType, jsonname, omitempty, originalname, param)
 		lines = appendblank(lines)
 	}
 	return strings.Join(lines, "\n")
 }
 
+// getReferenceKinds returns the configured or inferred kinds of a reference
+func (in *Types) getReferenceKinds(parent, name string) []string {
+	if in.References == nil {
+		in.References = reference.New(in.Resources)
+	}
+
+	kinds := []string{}
+	for _, kind := range in.References.Resolve(in.Resource, parent, name) {
+		kinds = append(kinds, kind.String())
+	}
+	return kinds
+}
+
+// getMarkers returns the kubebuilder validation markers for the property
+func (in *Types) getMarkers(name string, required bool, property resource.Property, goType string) []string {
+	markers := []string{}
+
+	// the name property defaults to the object name so it never has to be set
+	if required && name != in.Resource.Kind+"Name" {
+		markers = append(markers, "// +kubebuilder:validation:Required")
+	}
+
+	isJSON := property.GetType() == "Json" || property.GetItemType() == "Json"
+	if isJSON && !resource.IsMapTags(name, property) {
+		markers = append(markers, "// +kubebuilder:pruning:PreserveUnknownFields")
+	}
+
+	// patterns Kubernetes can't compile are reported and left out
+	validation, _ := property.GetValidation().ForKubernetes()
+	if validation == nil {
+		validation = &resource.Validation{}
+	}
+
+	switch {
+	case property.GetType() == "Double":
+		pattern := decimalPattern
+		if validation.Pattern != "" {
+			pattern = validation.Pattern
+		}
+		markers = append(markers, "// +kubebuilder:validation:Pattern="+markerString(pattern))
+	case goType == "string":
+		if len(validation.Enum) > 0 {
+			values := []string{}
+			for _, value := range validation.Enum {
+				values = append(values, markerValue(value))
+			}
+			markers = append(markers, "// +kubebuilder:validation:Enum="+strings.Join(values, ";"))
+		}
+		if validation.Pattern != "" {
+			markers = append(markers, "// +kubebuilder:validation:Pattern="+markerString(validation.Pattern))
+		}
+		if validation.MinLength != nil {
+			markers = append(markers, fmt.Sprintf("// +kubebuilder:validation:MinLength=%v", *validation.MinLength))
+		}
+		if validation.MaxLength != nil {
+			markers = append(markers, fmt.Sprintf("// +kubebuilder:validation:MaxLength=%v", *validation.MaxLength))
+		}
+	case goType == "int" || goType == "int64":
+		if validation.Minimum != nil {
+			markers = append(markers, "// +kubebuilder:validation:Minimum="+strconv.FormatFloat(*validation.Minimum, 'f', -1, 64))
+		}
+		if validation.Maximum != nil {
+			markers = append(markers, "// +kubebuilder:validation:Maximum="+strconv.FormatFloat(*validation.Maximum, 'f', -1, 64))
+		}
+	case strings.HasPrefix(goType, "[]"):
+		if validation.MinItems != nil {
+			markers = append(markers, fmt.Sprintf("// +kubebuilder:validation:MinItems=%v", *validation.MinItems))
+		}
+		if validation.MaxItems != nil {
+			markers = append(markers, fmt.Sprintf("// +kubebuilder:validation:MaxItems=%v", *validation.MaxItems))
+		}
+	}
+
+	return markers
+}
+
+// decimalPattern validates the string encoded Double properties
+const decimalPattern = `^-?[0-9]+(\.[0-9]+)?$`
+
+// getUpdateDocumentation warns about properties which replace the resource
+// when they are updated, transition rules can't be used within property types
+// because they might be part of a list
+func getUpdateDocumentation(property resource.Property, root bool) []string {
+	switch property.GetUpdateType() {
+	case resource.ImmutableType:
+		if root {
+			return []string{"//", "// This property is immutable and can't be changed once it's set."}
+		}
+		return []string{"//", "// Warning: updating this property replaces the resource."}
+	case resource.ConditionalType:
+		return []string{"//", "// Warning: updating this property might replace the resource."}
+	}
+	return []string{}
+}
+
+// getUpdateMarkers returns the marker tooling reads for the properties which
+// replace the resource when they are updated, always or conditionally
+func getUpdateMarkers(property resource.Property) []string {
+	switch property.GetUpdateType() {
+	case resource.ImmutableType:
+		return []string{"// +awsctrl:update:replacement=always"}
+	case resource.ConditionalType:
+		return []string{"// +awsctrl:update:replacement=conditional"}
+	}
+	return []string{}
+}
+
+// markerValue quotes enum values which aren't plain words
+func markerValue(value string) string {
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '-' && r != '_' {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
+
+// markerString quotes strings in backticks so regular expressions stay readable
+func markerString(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return "`" + value + "`"
+}
+
 // GetResourceProperties will return the props
 func (in *Types) GetResourceProperties() string {
-	return in.GetProperties(in.Resource.ResourceType.GetProperties())
+	return in.GetProperties(in.Resource.ResourceType.GetProperties(), "")
+}
+
+// GetOutputs will return the typed stack output fields
+func (in *Types) GetOutputs() string {
+	lines := []string{}
+
+	attributes := in.Resource.ResourceType.GetAttributes()
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		if !in.Resource.IncludesAttribute(name) {
+			continue
+		}
+		outputname := resource.OutputName(name)
+		lines = appendstrf(lines, `// %v defines the %v`, outputname, name)
+		lines = appendstrf(lines, `%v %v `+"`"+`json:"%v,omitempty" cloudformation:"%v,Output"`+"`", outputname, attributes[name].GetOutputType(), lowerfirst(outputname), outputname)
+		lines = appendblank(lines)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// UsesJSON checks if any of the generated properties is a Json property or a
+// list or map of Json, the template only imports runtime for their
+// RawExtension fields. The imports which aren't used are left out of the
+// template because imports.Process keeps or removes them depending on the
+// sibling files on disk, so the first and second run generated different files.
+func (in *Types) UsesJSON() bool {
+	types := map[string]resource.ResourceType{"": in.Resource.ResourceType}
+	for parent, propertytype := range in.Resource.PropertyTypes {
+		types[parent] = propertytype
+	}
+
+	for parent, propertytype := range types {
+		for name, property := range propertytype.GetProperties() {
+			isJSON := property.GetType() == "Json" || property.GetItemType() == "Json"
+			if isJSON && !resource.IsMapTags(name, property) && in.Resource.IncludesProperty(parent, name) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GetPropertyTypes will return the property types
@@ -126,7 +353,7 @@ func (in *Types) GetPropertyTypes() string {
 		resource := propertytype[resourcename]
 		lines = appendstrf(lines, `// %v_%v defines the desired state of %v%v`, in.Resource.Kind, resourcename, in.Resource.Kind, resourcename)
 		lines = appendstrf(lines, `type %v_%v struct {`, in.Resource.Kind, resourcename)
-		lines = appendstrf(lines, in.GetProperties(resource.GetProperties()))
+		lines = appendstrf(lines, in.GetProperties(resource.GetProperties(), resourcename))
 		lines = appendstrf(lines, `}`)
 		lines = appendblank(lines)
 	}
@@ -153,12 +380,11 @@ const typesTemplate = `{{ .Boilerplate }}
 package {{ .Resource.Version }}
 
 import (
-	"strings"
-
 	metav1alpha1 "go.awsctrl.io/manager/apis/meta/v1alpha1"
-	controllerutils "go.awsctrl.io/manager/controllers/utils"
-	cfnencoder "go.awsctrl.io/manager/encoding/cloudformation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	{{- if .UsesJSON }}
+	"k8s.io/apimachinery/pkg/runtime"
+	{{- end }}
 )
 
 // {{ .Resource.Kind }}Spec defines the desired state of {{ .Resource.Kind }}
@@ -180,10 +406,7 @@ type {{ .Resource.Kind }}Output struct {
 	// {{ .Resource.ResourceType.GetDocumentation }}
 	Ref string ` + "`" + `json:"ref,omitempty"` + "`" + `
 
-	{{ range $name, $attr := .Resource.ResourceType.GetAttributes }}
-	// {{ $name }} defines the {{ $name }}
-	{{ $name }} string ` + "`" + `json:"{{ $name | lowerfirst }},omitempty" cloudformation:"{{ $name }},Output"` + "`" + `
-	{{ end }}
+	{{ noescape .GetOutputs }}
 }
 
 // +kubebuilder:object:root=true
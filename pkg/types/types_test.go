@@ -0,0 +1,294 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"go.awsctrl.io/generator/apis/generator/v1alpha1"
+	"go.awsctrl.io/generator/pkg/cfnspec"
+	"go.awsctrl.io/generator/pkg/reference"
+	"go.awsctrl.io/generator/pkg/resource"
+	"go.awsctrl.io/generator/pkg/types"
+
+	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
+)
+
+func newTypes(props map[string]resource.Property) *types.Types {
+	return &types.Types{
+		Resource: &resource.Resource{
+			Resource: kbresource.Resource{
+				Group:   "ecr",
+				Version: "v1alpha1",
+				Kind:    "Repository",
+			},
+			ResourceType: &resource.BaseResource{
+				Properties: props,
+			},
+			PropertyTypes: map[string]resource.ResourceType{},
+		},
+	}
+}
+
+func TestTypes_GetProperties(t *testing.T) {
+	minLength := 2
+	maxItems := 50
+
+	tests := []struct {
+		name     string
+		property resource.Property
+		want     []string
+	}{
+		{"TestRequired", &resource.BaseProperty{Type: "String", Required: true}, []string{
+			"// +kubebuilder:validation:Required",
+		}},
+		{"TestStringValidation", &resource.BaseProperty{Type: "String", Validation: &resource.Validation{
+			Enum:      []string{"MUTABLE", "NOT MUTABLE"},
+			Pattern:   "^[a-z]+$",
+			MinLength: &minLength,
+		}}, []string{
+			`// +kubebuilder:validation:Enum=MUTABLE;"NOT MUTABLE"`,
+			"// +kubebuilder:validation:Pattern=`^[a-z]+$`",
+			"// +kubebuilder:validation:MinLength=2",
+		}},
+		{"TestListValidation", &resource.BaseProperty{Type: "List", ItemType: "String", Validation: &resource.Validation{
+			MaxItems: &maxItems,
+		}}, []string{
+			"// +kubebuilder:validation:MaxItems=50",
+		}},
+		{"TestDouble", &resource.BaseProperty{Type: "Double"}, []string{
+			"// +kubebuilder:validation:Pattern=`^-?[0-9]+(\\.[0-9]+)?$`",
+			"Field string `json:\"field,omitempty\" cloudformation:\"Field,Parameter\"`",
+		}},
+		{"TestLong", &resource.BaseProperty{Type: "Long"}, []string{
+			"Field int64 `json:\"field,omitempty\" cloudformation:\"Field,Parameter\"`",
+		}},
+		{"TestTimestamp", &resource.BaseProperty{Type: "Timestamp"}, []string{
+			"Field metav1.Time `json:\"field,omitempty\" cloudformation:\"Field\"`",
+		}},
+		{"TestJson", &resource.BaseProperty{Type: "Json"}, []string{
+			"// +kubebuilder:pruning:PreserveUnknownFields",
+			"Field *runtime.RawExtension `json:\"field,omitempty\" cloudformation:\"Field\"`",
+		}},
+		{"TestMapJson", &resource.BaseProperty{Type: "Map", ItemType: "Json"}, []string{
+			"// +kubebuilder:pruning:PreserveUnknownFields",
+			"Field map[string]runtime.RawExtension `json:\"field,omitempty\" cloudformation:\"Field\"`",
+		}},
+		{"TestImmutable", &resource.BaseProperty{Type: "String", UpdateType: resource.ImmutableType}, []string{
+			"// +awsctrl:update:replacement=always",
+			`// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="field is immutable"`,
+		}},
+		{"TestConditional", &resource.BaseProperty{Type: "String", UpdateType: resource.ConditionalType}, []string{
+			"// Warning: updating this property might replace the resource.",
+			"// +awsctrl:update:replacement=conditional",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newTypes(map[string]resource.Property{"Field": tt.property})
+
+			got := in.GetResourceProperties()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Types.GetResourceProperties() = %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTypes_GetOutputs(t *testing.T) {
+	in := newTypes(map[string]resource.Property{})
+	in.Resource.ResourceType.SetAttributes(map[string]resource.Attribute{
+		"Arn":            &resource.BaseAttribute{PrimitiveType: "String"},
+		"Endpoint.Port":  &resource.BaseAttribute{PrimitiveType: "Integer"},
+		"Size":           &resource.BaseAttribute{PrimitiveType: "Long"},
+		"Ipv6CidrBlocks": &resource.BaseAttribute{Type: "List", PrimitiveItemType: "String"},
+	})
+
+	got := in.GetOutputs()
+	for _, want := range []string{
+		"Arn string `json:\"arn,omitempty\" cloudformation:\"Arn,Output\"`",
+		"EndpointPort int `json:\"endpointPort,omitempty\" cloudformation:\"EndpointPort,Output\"`",
+		"Size int64 `json:\"size,omitempty\" cloudformation:\"Size,Output\"`",
+		"Ipv6CidrBlocks []string `json:\"ipv6CidrBlocks,omitempty\" cloudformation:\"Ipv6CidrBlocks,Output\"`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Types.GetOutputs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTypes_UsesJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]resource.Property
+		drop  bool
+		want  bool
+	}{
+		{"TestNoJSON", map[string]resource.Property{"Name": &resource.BaseProperty{Type: "String"}}, false, false},
+		{"TestJSON", map[string]resource.Property{"Policy": &resource.BaseProperty{Type: "Json"}}, false, true},
+		{"TestListJSON", map[string]resource.Property{"Policies": &resource.BaseProperty{Type: "List", ItemType: "Json"}}, false, true},
+		{"TestDroppedJSON", map[string]resource.Property{"Policy": &resource.BaseProperty{Type: "Json"}}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newTypes(tt.props)
+			in.Resource.Override = v1alpha1.Override{
+				Properties: map[string]v1alpha1.PropertyOverride{"Policy": {Drop: tt.drop}},
+			}
+			if got := in.UsesJSON(); got != tt.want {
+				t.Errorf("Types.UsesJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypes_GetPropertiesOverrides(t *testing.T) {
+	yes, no := true, false
+
+	in := newTypes(map[string]resource.Property{
+		"KmsKeyId":  &resource.BaseProperty{Type: "String"},
+		"ClientId":  &resource.BaseProperty{Type: "String"},
+		"Target":    &resource.BaseProperty{Type: "String"},
+		"Secret":    &resource.BaseProperty{Type: "String"},
+		"BucketArn": &resource.BaseProperty{Type: "String"},
+	})
+	in.Resource.Override = v1alpha1.Override{
+		Properties: map[string]v1alpha1.PropertyOverride{
+			"KmsKeyId":  {Reference: &no},
+			"ClientId":  {Reference: &no, Name: "Client", JSONName: "clientID", Required: &yes},
+			"Target":    {Reference: &yes},
+			"Secret":    {Drop: true},
+			"BucketArn": {},
+		},
+	}
+
+	got := in.GetResourceProperties()
+	for _, want := range []string{
+		"KmsKeyId string `json:\"kmsKeyId,omitempty\" cloudformation:\"KmsKeyId,Parameter\"`",
+		"// +kubebuilder:validation:Required\nClient string `json:\"clientID,omitempty\" cloudformation:\"ClientId,Parameter\"`",
+		"TargetRef metav1alpha1.ObjectReference `json:\"targetRef,omitempty\" cloudformation:\"Target,Parameter\"`",
+		"BucketRef metav1alpha1.ObjectReference `json:\"bucketRef,omitempty\" cloudformation:\"BucketArn,Parameter\"`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Types.GetResourceProperties() = %v, want %v", got, want)
+		}
+	}
+
+	if strings.Contains(got, "Secret") {
+		t.Errorf("Types.GetResourceProperties() = %v, want Secret dropped", got)
+	}
+}
+
+func TestTypes_GetPropertiesReferenceKinds(t *testing.T) {
+	in := newTypes(map[string]resource.Property{
+		"VpcId":   &resource.BaseProperty{Type: "String"},
+		"RoleArn": &resource.BaseProperty{Type: "String"},
+	})
+	in.Resources = []resource.Resource{
+		{Resource: kbresource.Resource{Group: "ec2", Version: "v1alpha1", Kind: "VPC"}},
+	}
+	in.Resource.Override = v1alpha1.Override{
+		Properties: map[string]v1alpha1.PropertyOverride{
+			"RoleArn": {Kinds: []string{"iam.Role"}},
+		},
+	}
+
+	got := in.GetResourceProperties()
+	for _, want := range []string{
+		"// VpcRef references a ec2.VPC",
+		"// +awsctrl:reference:kinds=ec2.VPC",
+		"// +awsctrl:reference:kinds=iam.Role",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Types.GetResourceProperties() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTypes_GetPropertiesReferenceIndex(t *testing.T) {
+	in := newTypes(map[string]resource.Property{
+		"SubnetId": &resource.BaseProperty{Type: "String"},
+	})
+	in.References = reference.New([]resource.Resource{
+		{Resource: kbresource.Resource{Group: "ec2", Version: "v1alpha1", Kind: "Subnet"}},
+	})
+
+	if got, want := in.GetResourceProperties(), "// +awsctrl:reference:kinds=ec2.Subnet"; !strings.Contains(got, want) {
+		t.Errorf("Types.GetResourceProperties() = %v, want the kinds from the index %v", got, want)
+	}
+}
+
+func TestTypes_GetPropertiesRegistryPatterns(t *testing.T) {
+	loader, err := cfnspec.NewLoader(afero.NewOsFs(), "../cfnspec/testdata/registry", cfnspec.RegistryFormat)
+	if err != nil {
+		t.Fatalf("cfnspec.NewLoader() error = %v", err)
+	}
+
+	spec := cfnspec.New(loader, []string{"ecr"}, []string{})
+	if err := spec.Parse(); err != nil {
+		t.Fatalf("cfnspec.Parse() error = %v", err)
+	}
+
+	resources := spec.GetResources()
+	in := &types.Types{Resource: &resources[0], Resources: resources}
+
+	got := in.GetResourceProperties()
+	if strings.Contains(got, "(?=") {
+		t.Errorf("Types.GetResourceProperties() = %v, want no lookaheads", got)
+	}
+
+	for _, want := range []string{
+		"// +kubebuilder:validation:Pattern=`^((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*)$`",
+		"// +kubebuilder:validation:MinLength=2",
+		"// +kubebuilder:validation:MaxLength=256",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Types.GetResourceProperties() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTypes_GetPropertiesMapTags(t *testing.T) {
+	loader, err := cfnspec.NewLoader(afero.NewOsFs(), "../cfnspec/testdata/CloudFormationResourceSpecification.json", "")
+	if err != nil {
+		t.Fatalf("cfnspec.NewLoader() error = %v", err)
+	}
+
+	spec := cfnspec.New(loader, []string{"ssm"}, []string{})
+	if err := spec.Parse(); err != nil {
+		t.Fatalf("cfnspec.Parse() error = %v", err)
+	}
+
+	resources := spec.GetResources()
+	in := &types.Types{Resource: &resources[0], Resources: resources}
+
+	got := in.GetResourceProperties()
+	if want := "Tags []Parameter_Tag `json:\"tags,omitempty\" cloudformation:\"Tags\"`"; !strings.Contains(got, want) {
+		t.Errorf("Types.GetResourceProperties() = %v, want %v", got, want)
+	}
+	if strings.Contains(got, "PreserveUnknownFields") {
+		t.Errorf("Types.GetResourceProperties() = %v, want the tags typed", got)
+	}
+	if in.UsesJSON() {
+		t.Errorf("Types.UsesJSON() = true, want false")
+	}
+}
@@ -18,7 +18,9 @@ limitations under the License.
 package yaml
 
 import (
+	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"go.awsctrl.io/generator/pkg/input"
@@ -26,6 +28,7 @@ import (
 )
 
 var _ input.File = &YAML{}
+var _ input.Template = &YAML{}
 
 // YAML scaffolds the config/samples/<group>/<version>_<kind>.yaml
 type YAML struct {
@@ -50,6 +53,66 @@ func (in *YAML) GetInput() input.Input {
 // ShouldOverride will tell the scaffolder to override existing files
 func (in *YAML) ShouldOverride() bool { return false }
 
+// TemplateName returns the name of the template in the templates directory
+func (in *YAML) TemplateName() string { return "sample" }
+
+// DefaultTemplate returns the template shipped with the generator
+func (in *YAML) DefaultTemplate() string { return groupTemplate }
+
+// GetSpec returns the required properties with empty values
+func (in *YAML) GetSpec() string {
+	lines := []string{}
+
+	props := in.Resource.ResourceType.GetProperties()
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		property := props[name]
+		// the name property defaults to the object name
+		if name == in.Resource.Kind+"Name" ||
+			!in.Resource.IncludesProperty("", name) ||
+			!in.Resource.IsRequired("", name, property) {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("  %v: %v", in.Resource.GetJSONName("", name, property), in.getValue(name, property)))
+	}
+
+	if len(lines) == 0 {
+		return "spec: {}"
+	}
+	return strings.Join(append([]string{"spec:"}, lines...), "\n")
+}
+
+// getValue returns an empty value for the property type
+func (in *YAML) getValue(name string, property resource.Property) string {
+	switch {
+	case in.Resource.IsReference("", name, property):
+		return "{}"
+	case property.IsList():
+		return "[]"
+	case property.GetType() == "Double":
+		// doubles are decimal strings
+		return `"0"`
+	case property.GetType() == "Timestamp":
+		return `"1970-01-01T00:00:00Z"`
+	}
+
+	switch property.GetGoType(in.Resource.Kind) {
+	case "string":
+		return `""`
+	case "int", "int64":
+		return "0"
+	case "bool":
+		return "false"
+	}
+	return "{}"
+}
+
 // Validate validates the values
 func (in *YAML) Validate() error {
 	return in.Resource.Validate()
@@ -59,4 +122,5 @@ const groupTemplate = `apiVersion: {{ .Resource.Group | lower }}.awsctrl.io/{{ .
 kind: {{ .Resource.Kind }}
 metadata:
   name: {{ .Resource.Kind | lower }}-sample
-spec: {}`
+{{ noescape .GetSpec }}
+`
@@ -0,0 +1,57 @@
+/*
+Copyright © 2019 AWS Controller authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yaml_test
+
+import (
+	"testing"
+
+	"go.awsctrl.io/generator/pkg/resource"
+	"go.awsctrl.io/generator/pkg/yaml"
+
+	kbresource "sigs.k8s.io/kubebuilder/pkg/scaffold/resource"
+)
+
+func TestYAML_GetSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		property resource.Property
+		want     string
+	}{
+		{"TestString", &resource.BaseProperty{Type: "String", Required: true}, "spec:\n  value: \"\""},
+		{"TestLong", &resource.BaseProperty{Type: "Long", Required: true}, "spec:\n  value: 0"},
+		{"TestDouble", &resource.BaseProperty{Type: "Double", Required: true}, "spec:\n  value: \"0\""},
+		{"TestTimestamp", &resource.BaseProperty{Type: "Timestamp", Required: true}, "spec:\n  value: \"1970-01-01T00:00:00Z\""},
+		{"TestList", &resource.BaseProperty{Type: "List", ItemType: "String", Required: true}, "spec:\n  value: []"},
+		{"TestOptional", &resource.BaseProperty{Type: "Double"}, "spec: {}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &yaml.YAML{
+				Resource: &resource.Resource{
+					Resource: kbresource.Resource{Group: "ecr", Version: "v1alpha1", Kind: "Repository"},
+					ResourceType: &resource.BaseResource{
+						Properties: map[string]resource.Property{"Value": tt.property},
+					},
+				},
+			}
+
+			if got := in.GetSpec(); got != tt.want {
+				t.Errorf("YAML.GetSpec() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}